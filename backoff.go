@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package chrono
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// _defaultBackoffFactor is the factor used by a [BackoffConfig] whose Factor
+// is not greater than 1.
+const _defaultBackoffFactor = 2
+
+// A BackoffConfig configures a [Backoff].
+type BackoffConfig struct {
+	// Initial is the interval returned by the first call to [Backoff.Next],
+	// and after each call to [Backoff.Reset]. If Initial is not greater than
+	// zero, Next always returns zero.
+	Initial time.Duration
+	// Max caps the interval before jitter is applied. If Max is not greater
+	// than zero, the interval is not capped.
+	Max time.Duration
+	// Factor is multiplied with the interval after each call to
+	// [Backoff.Next]. If Factor is not greater than 1, a factor of 2 is used.
+	Factor float64
+	// Jitter is the maximum fraction, in [0, 1], by which each interval is
+	// randomly shortened, so that many callers backing off at the same time
+	// do not retry in lockstep. Values outside of [0, 1] are clamped.
+	Jitter float64
+	// Rand is the source of randomness used to apply jitter, which must
+	// return values in [0, 1), such as [rand.Rand.Float64]. It is only called
+	// while the [Backoff] is locked, and so need not be safe for concurrent
+	// use. If Rand is nil, [rand.Float64] is used.
+	Rand func() float64
+}
+
+// NextAfter returns the interval that follows cur, before jitter is applied:
+// cur multiplied by the configured factor, or the initial interval if cur is
+// not greater than zero, capped at the configured maximum. NextAfter is
+// useful to callers that track the current interval themselves.
+func (c BackoffConfig) NextAfter(cur time.Duration) time.Duration {
+	if c.Initial <= 0 {
+		return 0
+	}
+
+	next := c.Initial
+	if cur > 0 {
+		factor := c.Factor
+		if factor <= 1 {
+			factor = _defaultBackoffFactor
+		}
+
+		next = time.Duration(float64(cur) * factor)
+		if next < cur {
+			// Overflow.
+			next = cur
+		}
+	}
+
+	if c.Max > 0 && next > c.Max {
+		next = c.Max
+	}
+	return next
+}
+
+// A Backoff computes successive intervals to wait between retries, growing
+// exponentially from an initial interval up to a maximum, with optional jitter.
+// A Backoff does not tell time itself, and so can be used with any clock. A
+// Backoff is safe for concurrent use.
+type Backoff struct {
+	config BackoffConfig
+	cur    time.Duration
+	mu     sync.Mutex
+}
+
+// NewBackoff returns a new [Backoff] configured by cfg.
+func NewBackoff(cfg BackoffConfig) *Backoff {
+	if cfg.Factor <= 1 {
+		cfg.Factor = _defaultBackoffFactor
+	}
+	cfg.Jitter = min(max(cfg.Jitter, 0), 1)
+	if cfg.Rand == nil {
+		cfg.Rand = rand.Float64
+	}
+
+	return &Backoff{
+		config: cfg,
+	}
+}
+
+// Next returns the next interval to wait. The first call returns the initial
+// interval, and each subsequent call returns the previous interval multiplied
+// by the configured factor, up to the configured maximum; jitter is then
+// applied to the result, shortening it by a random fraction of up to the
+// configured jitter.
+func (b *Backoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	next := b.config.NextAfter(b.cur)
+	b.cur = next
+
+	if b.config.Jitter > 0 {
+		next -= time.Duration(float64(next) * b.config.Jitter * b.config.Rand())
+	}
+	return next
+}
+
+// Reset resets b so that the next call to [Backoff.Next] returns the initial
+// interval.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cur = 0
+}
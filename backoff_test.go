@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package chrono_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono"
+)
+
+func TestBackoff(t *testing.T) {
+	backoff := chrono.NewBackoff(chrono.BackoffConfig{
+		Initial: time.Second,
+		Max:     5 * time.Second,
+		Factor:  2,
+	})
+
+	for _, want := range []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+	} {
+		require.Equal(t, want, backoff.Next())
+	}
+
+	backoff.Reset()
+	require.Equal(t, time.Second, backoff.Next())
+}
+
+func TestBackoff_Defaults(t *testing.T) {
+	// Without a max or a valid factor, intervals double without bound.
+	backoff := chrono.NewBackoff(chrono.BackoffConfig{
+		Initial: time.Second,
+	})
+
+	want := time.Second
+	for i := 0; i < 10; i++ {
+		require.Equal(t, want, backoff.Next())
+		want *= 2
+	}
+
+	// Without an initial interval, intervals are always zero.
+	backoff = chrono.NewBackoff(chrono.BackoffConfig{})
+	for i := 0; i < 3; i++ {
+		require.Zero(t, backoff.Next())
+	}
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	var (
+		samples = []float64{0, 0.5, 0.99}
+		backoff = chrono.NewBackoff(chrono.BackoffConfig{
+			Initial: time.Second,
+			Jitter:  0.5,
+			Rand: func() float64 {
+				sample := samples[0]
+				samples = samples[1:]
+				return sample
+			},
+		})
+	)
+
+	// Jitter shortens each interval without affecting subsequent intervals.
+	require.Equal(t, time.Second, backoff.Next())
+	require.Equal(t, 1500*time.Millisecond, backoff.Next())
+	require.Equal(t, 4*time.Second-1980*time.Millisecond, backoff.Next())
+
+	// Jitter is clamped to [0, 1].
+	backoff = chrono.NewBackoff(chrono.BackoffConfig{
+		Initial: time.Second,
+		Jitter:  2,
+		Rand:    rand.New(rand.NewSource(1)).Float64,
+	})
+	for i := 0; i < 10; i++ {
+		require.GreaterOrEqual(t, backoff.Next(), time.Duration(0))
+	}
+
+	// Without a source of randomness, the global source is used.
+	backoff = chrono.NewBackoff(chrono.BackoffConfig{
+		Initial: time.Second,
+		Jitter:  1,
+	})
+	require.LessOrEqual(t, backoff.Next(), time.Second)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// A BroadcastTicker fans out ticks from a single underlying [Ticker] to any
+// number of subscribers, rather than delivering each tick to whichever
+// receiver happens to be ready first. Every subscriber receives every tick,
+// unless it is not ready to receive it: like a [time.Ticker], ticks are
+// dropped for slow subscribers so that they cannot stall the ticker or other
+// subscribers. A BroadcastTicker is safe for concurrent use.
+type BroadcastTicker struct {
+	ticker *Ticker
+	subs   map[<-chan time.Time]chan time.Time
+	done   chan struct{}
+	exited chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+}
+
+// NewBroadcastTicker returns a new [BroadcastTicker] that ticks every d, as
+// measured by clk. The ticker must be stopped with [BroadcastTicker.Stop] once
+// it is no longer needed.
+func NewBroadcastTicker(clk Clock, d time.Duration) *BroadcastTicker {
+	t := &BroadcastTicker{
+		ticker: clk.NewTicker(d),
+		subs:   make(map[<-chan time.Time]chan time.Time),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Subscribe returns a new channel on which every subsequent tick is delivered.
+// The channel is never closed; subscribers that are no longer interested in
+// ticks should call [BroadcastTicker.Unsubscribe].
+func (t *BroadcastTicker) Subscribe() <-chan time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	t.subs[ch] = ch
+	return ch
+}
+
+// Unsubscribe stops delivering ticks to ch, which must have been returned by
+// [BroadcastTicker.Subscribe]. Unsubscribe does not close ch. Unsubscribing a
+// channel that is not subscribed has no effect.
+func (t *BroadcastTicker) Unsubscribe(ch <-chan time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.subs, ch)
+}
+
+// Stop turns off the ticker. Once Stop returns, no more ticks are delivered to
+// any subscriber. Stop does not close subscriber channels.
+func (t *BroadcastTicker) Stop() {
+	t.once.Do(func() {
+		t.ticker.Stop()
+		close(t.done)
+	})
+	<-t.exited
+}
+
+func (t *BroadcastTicker) run() {
+	defer close(t.exited)
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case now := <-t.ticker.C:
+			t.broadcast(now)
+		}
+	}
+}
+
+func (t *BroadcastTicker) broadcast(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, ch := range t.subs {
+		select {
+		case ch <- now:
+		default:
+		}
+	}
+}
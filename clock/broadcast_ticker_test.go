@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"go.mway.dev/chrono/clock"
+)
+
+func TestBroadcastTicker(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		ticker = clock.NewBroadcastTicker(clk, time.Second)
+		subA   = ticker.Subscribe()
+		subB   = ticker.Subscribe()
+	)
+	defer ticker.Stop()
+
+	// Every subscriber receives every tick.
+	for i := 1; i <= 3; i++ {
+		clk.Add(time.Second)
+		want := int64(i) * int64(time.Second)
+		requireTimeIs(t, want, requireTick(t, subA))
+		requireTimeIs(t, want, requireTick(t, subB))
+	}
+
+	// Slow subscribers do not stall the others: subB misses the fifth tick
+	// because it has not received the fourth. Unsubscribing waits for any
+	// in-progress broadcast, so no ticks are delivered to subB afterward.
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(4*time.Second), requireTick(t, subA))
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(5*time.Second), requireTick(t, subA))
+	ticker.Unsubscribe(subB)
+	ticker.Unsubscribe(subB)
+	requireTimeIs(t, int64(4*time.Second), requireTick(t, subB))
+	requireNoTick(t, subB)
+
+	// Unsubscribed channels no longer receive ticks.
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(6*time.Second), requireTick(t, subA))
+	requireNoTick(t, subB)
+
+	// Stopped tickers no longer deliver ticks.
+	ticker.Stop()
+	ticker.Stop()
+	clk.Add(time.Second)
+	requireNoTick(t, subA)
+}
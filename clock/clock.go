@@ -22,6 +22,7 @@
 package clock
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -44,12 +45,38 @@ type Clock interface {
 	// its [Timer.Stop] method.
 	AfterFunc(d time.Duration, fn func()) *Timer
 
+	// AfterFuncContext is like [AfterFunc], but fn is not called if ctx is
+	// done before d has elapsed, in which case the underlying [Timer] is
+	// stopped. Either fn is called or ctx is observed to be done, never both.
+	AfterFuncContext(ctx context.Context, d time.Duration, fn func())
+
+	// DeadlineContext returns a copy of parent that is canceled once the
+	// clock's time reaches ns, as reported by [Nanotime], or when the returned
+	// cancel function is called, or when parent is done, whichever happens
+	// first. Once the deadline is reached, the context's Err method returns
+	// [context.DeadlineExceeded]. Canceling the context releases resources
+	// associated with it, so code should call cancel as soon as the operations
+	// running in the context complete.
+	DeadlineContext(
+		parent context.Context,
+		ns int64,
+	) (context.Context, context.CancelFunc)
+
 	// Nanotime returns the current time in nanoseconds.
 	Nanotime() int64
 
 	// NewStopwatch returns a new [Stopwatch] that uses the [Clock] for
-	// measuring time.
-	NewStopwatch() *Stopwatch
+	// measuring time, configured by the given options.
+	NewStopwatch(opts ...StopwatchOption) *Stopwatch
+
+	// NewAlignedTicker returns a new [Ticker] like [NewTicker], except that
+	// ticks are aligned to multiples of d of the clock's time (for wall
+	// clocks, multiples of d since the Unix epoch): the first tick is sent at
+	// the next such boundary, and subsequent ticks every d thereafter. This is
+	// useful for e.g. running a job at the top of every minute. The duration
+	// d must be greater than zero; if not, NewAlignedTicker will panic.
+	// Resetting the ticker discards its alignment.
+	NewAlignedTicker(d time.Duration) *Ticker
 
 	// NewTicker returns a new [Ticker] containing a channel that will send the
 	// current time on the channel after each tick. The period of the ticks is
@@ -59,6 +86,13 @@ type Clock interface {
 	// to release associated resources.
 	NewTicker(d time.Duration) *Ticker
 
+	// NewTickerFunc returns a new [Ticker] that calls fn in its own goroutine
+	// after each tick. The period of the ticks is specified by the duration
+	// argument. The duration d must be greater than zero; if not,
+	// NewTickerFunc will panic. Stop the ticker to stop further calls to fn
+	// and to release associated resources.
+	NewTickerFunc(d time.Duration, fn func()) *Ticker
+
 	// NewTimer creates a new [Timer] that will send the current time on its
 	// channel after at least d has elapsed.
 	NewTimer(d time.Duration) *Timer
@@ -66,6 +100,9 @@ type Clock interface {
 	// Now returns the current time. For wall clocks, this is the local time;
 	// for monotonic clocks, this is the system's monotonic time. Other Clock
 	// implementations may have different locale or clock time semantics.
+	// The returned [time.Time] is a value and does not escape to the heap,
+	// so calling Now does not allocate; prefer Nanotime only when integer
+	// nanoseconds are what is needed.
 	Now() time.Time
 
 	// Since returns the time elapsed since t. It is shorthand for
@@ -80,14 +117,49 @@ type Clock interface {
 	// negative or zero duration causes Sleep to return immediately.
 	Sleep(d time.Duration)
 
+	// SleepUntil pauses the current goroutine until the clock's time reaches
+	// t. If the clock's time is already at or after t, SleepUntil returns
+	// immediately.
+	SleepUntil(t time.Time)
+
+	// SleepUntilNanotime is like [SleepUntil], but the target time is given as
+	// integer nanoseconds, as reported by [Nanotime].
+	SleepUntilNanotime(ns int64)
+
+	// Snapshot returns the current time both as a [time.Time] and as integer
+	// nanoseconds, derived from a single reading of the clock, so that the two
+	// always describe the same instant. Calling Now and Nanotime separately
+	// reads the clock twice, and the results may disagree.
+	Snapshot() (now time.Time, ns int64)
+
 	// Tick is a convenience wrapper for [NewTicker] providing access to the
 	// ticking channel only. While Tick is useful for clients that have no need
 	// to shut down the [Ticker], be aware that without a way to shut it down
 	// the underlying Ticker cannot be recovered by the garbage collector; it
 	// "leaks". Like [NewTicker], Tick will panic if d <= 0.
 	Tick(time.Duration) <-chan time.Time
+
+	// TickContext is like [Tick], but the underlying [Ticker] is stopped and
+	// the returned channel is closed once ctx is done, rather than leaking.
+	// Like [NewTicker], TickContext will panic if d <= 0.
+	TickContext(ctx context.Context, d time.Duration) <-chan time.Time
+
+	// TimeoutContext is like [DeadlineContext], but the context is canceled
+	// once the clock has advanced by d from the time that TimeoutContext is
+	// called. For clocks backed by the system's time, it is equivalent to
+	// [context.WithTimeout].
+	TimeoutContext(
+		parent context.Context,
+		d time.Duration,
+	) (context.Context, context.CancelFunc)
 }
 
+// Shared instances of the system's clocks, returned by [Monotonic] and [Wall].
+var (
+	_monotonic = NewMonotonicClock()
+	_wall      = NewWallClock()
+)
+
 // NewClock returns a new [Clock] based on the given options.
 func NewClock(opts ...Option) (Clock, error) {
 	options := DefaultOptions()
@@ -96,10 +168,36 @@ func NewClock(opts ...Option) (Clock, error) {
 	}
 
 	if options.NanotimeFunc != nil {
-		return newMonotonicClock(options.NanotimeFunc), nil
+		fn := options.NanotimeFunc
+		if res := options.Resolution; res > 0 {
+			fn = func() int64 {
+				return truncateNanotime(options.NanotimeFunc(), res)
+			}
+		}
+		return newMonotonicClock(fn), nil
+	}
+
+	fn := options.TimeFunc
+	if res := options.Resolution; res > 0 {
+		fn = func() time.Time {
+			// Monotonic readings are stripped so that comparisons between
+			// times use the truncated wall time.
+			var (
+				now = options.TimeFunc().Round(0)
+				ns  = now.UnixNano()
+			)
+			return now.Add(time.Duration(truncateNanotime(ns, res) - ns))
+		}
 	}
 
-	return newWallClock(options.TimeFunc), nil
+	if loc := options.Location; loc != nil {
+		base := fn
+		fn = func() time.Time {
+			return base().In(loc)
+		}
+	}
+
+	return newWallClock(fn), nil
 }
 
 // MustClock panics if the given error is not nil, otherwise it returns the
@@ -112,6 +210,22 @@ func MustClock(clock Clock, err error) Clock {
 	return clock
 }
 
+// Monotonic returns a shared monotonic [Clock], equivalent to one returned by
+// [NewMonotonicClock]. Because the system's clocks are stateless, the shared
+// instance is safe for concurrent use and may be used anywhere that a real
+// clock is needed without constructing a new one. Tests should generally use a
+// [FakeClock] instead.
+func Monotonic() Clock {
+	return _monotonic
+}
+
+// Wall returns a shared wall [Clock], equivalent to one returned by
+// [NewWallClock]. Like [Monotonic], the shared instance is safe for concurrent
+// use.
+func Wall() Clock {
+	return _wall
+}
+
 // NewMonotonicClock returns a new monotonic [Clock].
 func NewMonotonicClock() Clock {
 	return MustClock(NewClock(WithNanotimeFunc(DefaultNanotimeFunc())))
@@ -121,3 +235,31 @@ func NewMonotonicClock() Clock {
 func NewWallClock() Clock {
 	return MustClock(NewClock(WithTimeFunc(DefaultTimeFunc())))
 }
+
+// NewUTCWallClock returns a new wall [Clock] that reports time in UTC.
+func NewUTCWallClock() Clock {
+	return MustClock(NewClock(
+		WithTimeFunc(DefaultTimeFunc()),
+		WithLocation(time.UTC),
+	))
+}
+
+// NewMonotonicWallClock returns a new wall [Clock] whose time never moves
+// backward. Time is read from the system's wall clock, but is clamped to the
+// latest time seen so far, so that e.g. NTP adjustments cannot cause elapsed
+// times to become negative. The tradeoff is that, while the system clock is
+// behind the latest time seen, the clock appears to stall rather than move
+// backward.
+func NewMonotonicWallClock() Clock {
+	return newMonotonicClock(
+		NondecreasingNanotimeFunc(DefaultWallNanotimeFunc()),
+	)
+}
+
+// sleepUntil sleeps on clk until its time reaches ns, returning immediately if
+// it already has.
+func sleepUntil(clk Clock, ns int64) {
+	if d := time.Duration(ns - clk.Nanotime()); d > 0 {
+		clk.Sleep(d)
+	}
+}
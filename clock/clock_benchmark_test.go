@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"go.mway.dev/chrono/clock"
+)
+
+func BenchmarkClockNow(b *testing.B) {
+	cases := map[string]clock.Clock{
+		"mono":     clock.NewMonotonicClock(),
+		"wall":     clock.NewWallClock(),
+		"utc-wall": clock.NewUTCWallClock(),
+		"fake":     clock.NewFakeClock(),
+	}
+
+	for name, clk := range cases {
+		clk := clk
+		b.Run(name, func(b *testing.B) {
+			var now time.Time
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				now = clk.Now()
+			}
+
+			_ = now
+		})
+	}
+}
+
+func BenchmarkTimerPool(b *testing.B) {
+	clk := clock.NewMonotonicClock()
+
+	b.Run("pooled", func(b *testing.B) {
+		pool := clock.NewTimerPool(clk)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			pool.Put(pool.Get(time.Hour))
+		}
+	})
+
+	b.Run("unpooled", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			clk.NewTimer(time.Hour).Stop()
+		}
+	})
+}
@@ -21,11 +21,14 @@
 package clock_test
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono"
 	"go.mway.dev/chrono/clock"
 	"go.uber.org/atomic"
 )
@@ -146,6 +149,18 @@ func TestSpecializedClockConstructors(t *testing.T) {
 		"NewWallClock": {
 			clock: clock.NewWallClock(),
 		},
+		"NewMonotonicWallClock": {
+			clock: clock.NewMonotonicWallClock(),
+		},
+		"NewHybridClock": {
+			clock: clock.NewHybridClock(),
+		},
+		"Monotonic": {
+			clock: clock.Monotonic(),
+		},
+		"Wall": {
+			clock: clock.Wall(),
+		},
 	}
 
 	for name, tt := range cases {
@@ -161,6 +176,216 @@ func TestSpecializedClockConstructors(t *testing.T) {
 	}
 }
 
+func TestSharedClocks(t *testing.T) {
+	require.Same(t, clock.Monotonic(), clock.Monotonic())
+	require.Same(t, clock.Wall(), clock.Wall())
+	require.NotSame(t, clock.Monotonic(), clock.NewMonotonicClock())
+}
+
+func TestNewUTCWallClock(t *testing.T) {
+	clk := clock.NewUTCWallClock()
+	require.Equal(t, time.UTC, clk.Now().Location())
+	require.InEpsilon(
+		t,
+		time.Now().UnixNano(),
+		clk.Nanotime(),
+		float64(time.Second),
+	)
+}
+
+func TestWithLocation(t *testing.T) {
+	var (
+		loc    = time.FixedZone("test", -7*60*60)
+		ts     = time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+		timeFn = func() time.Time { return ts }
+		cases  = map[string]struct {
+			opts      []clock.Option
+			expectLoc *time.Location
+		}{
+			"without location": {
+				opts:      []clock.Option{clock.WithTimeFunc(timeFn)},
+				expectLoc: time.UTC,
+			},
+			"with location": {
+				opts: []clock.Option{
+					clock.WithTimeFunc(timeFn),
+					clock.WithLocation(loc),
+				},
+				expectLoc: loc,
+			},
+			"with location options": {
+				opts: []clock.Option{clock.Options{
+					TimeFunc: timeFn,
+					Location: loc,
+				}},
+				expectLoc: loc,
+			},
+			"with nil location": {
+				opts: []clock.Option{
+					clock.WithTimeFunc(timeFn),
+					clock.WithLocation(nil),
+				},
+				expectLoc: time.UTC,
+			},
+		}
+	)
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			clk := newTestClock(t, tt.opts...)
+			require.Equal(t, tt.expectLoc, clk.Now().Location())
+			require.True(t, ts.Equal(clk.Now()))
+			require.Equal(t, ts.UnixNano(), clk.Nanotime())
+		})
+	}
+}
+
+func TestWithResolution(t *testing.T) {
+	var (
+		ts     = time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC)
+		ns     = atomic.NewInt64(ts.UnixNano())
+		timeFn = func() time.Time { return time.Unix(0, ns.Load()).UTC() }
+		cases  = map[time.Duration]int64{
+			time.Nanosecond:  ts.UnixNano(),
+			time.Microsecond: ts.Truncate(time.Microsecond).UnixNano(),
+			time.Millisecond: ts.Truncate(time.Millisecond).UnixNano(),
+			time.Second:      ts.Truncate(time.Second).UnixNano(),
+			7 * time.Second:  ts.UnixNano() - ts.UnixNano()%int64(7*time.Second),
+		}
+	)
+
+	for res, want := range cases {
+		t.Run(res.String(), func(t *testing.T) {
+			wall := newTestClock(
+				t,
+				clock.WithTimeFunc(timeFn),
+				clock.WithResolution(res),
+			)
+			require.Equal(t, want, wall.Nanotime())
+			require.Equal(t, want, wall.Now().UnixNano())
+			require.Equal(t, time.UTC, wall.Now().Location())
+
+			mono := newTestClock(
+				t,
+				clock.WithNanotimeFunc(ns.Load),
+				clock.WithResolution(res),
+			)
+			require.Equal(t, want, mono.Nanotime())
+			require.Equal(t, want, mono.Now().UnixNano())
+		})
+	}
+
+	// Negative times are truncated toward negative infinity.
+	mono := newTestClock(
+		t,
+		clock.WithNanotimeFunc(func() int64 { return -1 }),
+		clock.WithResolution(time.Second),
+	)
+	require.Equal(t, -int64(time.Second), mono.Nanotime())
+
+	// Locations are still honored.
+	loc := time.FixedZone("test", -7*60*60)
+	wall := newTestClock(
+		t,
+		clock.WithTimeFunc(timeFn),
+		clock.WithResolution(time.Second),
+		clock.WithLocation(loc),
+	)
+	require.Equal(t, loc, wall.Now().Location())
+	require.Equal(t, ts.Truncate(time.Second).UnixNano(), wall.Now().UnixNano())
+
+	// Deadlines are computed relative to the truncated time: a deadline at the
+	// truncated time has already passed.
+	ctx, cancel := mono.DeadlineContext(context.Background(), -int64(time.Second))
+	defer cancel()
+	requireContextDone(t, ctx)
+
+	// A non-positive resolution has no effect.
+	for _, res := range []time.Duration{0, -1} {
+		mono = newTestClock(
+			t,
+			clock.WithNanotimeFunc(ns.Load),
+			clock.WithResolution(res),
+		)
+		require.Equal(t, ts.UnixNano(), mono.Nanotime())
+	}
+}
+
+func TestNewMonotonicWallClock(t *testing.T) {
+	var (
+		clk    = clock.NewMonotonicWallClock()
+		before = time.Now()
+		now    = clk.Now()
+		after  = time.Now()
+	)
+
+	require.False(t, now.Before(before.Truncate(0)))
+	require.False(t, now.After(after.Truncate(0)))
+	require.GreaterOrEqual(t, clk.Since(now), time.Duration(0))
+}
+
+func TestNewHybridClock(t *testing.T) {
+	var (
+		clk    = clock.NewHybridClock()
+		before = chrono.Nanotime()
+		ns     = clk.Nanotime()
+		after  = chrono.Nanotime()
+	)
+
+	// Nanotime is monotonic time, while Now is wall time.
+	require.GreaterOrEqual(t, ns, before)
+	require.LessOrEqual(t, ns, after)
+	require.WithinDuration(t, time.Now(), clk.Now(), time.Second)
+
+	now, ns := clk.Snapshot()
+	require.WithinDuration(t, time.Now(), now, time.Second)
+	require.InDelta(t, chrono.Nanotime(), ns, float64(time.Second))
+
+	// Snapshots are coherent: the wall times of two snapshots are exactly as
+	// far apart as their monotonic times.
+	later, laterNs := clk.Snapshot()
+	require.Equal(t, time.Duration(laterNs-ns), later.Sub(now))
+
+	// Each kind of time is measured against its own source.
+	start := clk.Now()
+	startNs := clk.Nanotime()
+	clk.SleepUntil(start.Add(10 * time.Millisecond))
+	require.GreaterOrEqual(t, clk.Since(start), 10*time.Millisecond)
+	clk.SleepUntilNanotime(startNs + int64(20*time.Millisecond))
+	require.GreaterOrEqual(t, clk.SinceNanotime(startNs), 20*time.Millisecond)
+
+	stopwatch := clk.NewStopwatch()
+	clk.Sleep(time.Millisecond)
+	require.GreaterOrEqual(t, stopwatch.Elapsed(), time.Millisecond)
+}
+
+func TestNondecreasingNanotimeFunc(t *testing.T) {
+	var (
+		src = atomic.NewInt64(100)
+		fn  = clock.NondecreasingNanotimeFunc(src.Load)
+	)
+
+	require.EqualValues(t, 100, fn())
+
+	src.Store(200)
+	require.EqualValues(t, 200, fn())
+
+	// Moving backward stalls at the latest time seen.
+	src.Store(150)
+	require.EqualValues(t, 200, fn())
+	src.Store(-1)
+	require.EqualValues(t, 200, fn())
+
+	// Moving forward again resumes normally.
+	src.Store(201)
+	require.EqualValues(t, 201, fn())
+
+	clk := clock.MustClock(clock.NewClock(clock.WithNanotimeFunc(fn)))
+	src.Store(0)
+	require.EqualValues(t, 201, clk.Nanotime())
+	require.Zero(t, clk.SinceNanotime(201))
+}
+
 func TestClock_NewTimer(t *testing.T) {
 	cases := map[string]struct {
 		opts []clock.Option
@@ -188,6 +413,127 @@ func TestClock_NewTimer(t *testing.T) {
 	}
 }
 
+func TestClock_Timer_ResetFunc(t *testing.T) {
+	var (
+		clk    = newTestClock(t, _withNanotimeFunc)
+		first  = make(chan struct{}, 1)
+		second = make(chan struct{}, 1)
+		timer  = clk.AfterFunc(time.Hour, func() {
+			first <- struct{}{}
+		})
+	)
+
+	require.True(t, timer.ResetFunc(time.Millisecond, func() {
+		second <- struct{}{}
+	}))
+	requireRecv(t, second)
+	require.False(t, timer.Active())
+	require.Empty(t, first)
+
+	require.False(t, timer.ResetFunc(time.Hour, func() {}))
+	require.True(t, timer.Active())
+	require.True(t, timer.Stop())
+
+	require.Panics(t, func() {
+		timer.ResetFunc(time.Hour, nil)
+	})
+}
+
+func TestClock_Timer_ResetFunc_Concurrent(t *testing.T) {
+	var (
+		clk   = newTestClock(t, _withNanotimeFunc)
+		timer = clk.AfterFunc(time.Hour, func() {})
+		wg    sync.WaitGroup
+	)
+	defer timer.Stop()
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			timer.ResetFunc(time.Hour, func() {})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			timer.Active()
+			timer.Reset(time.Hour)
+			timer.Stop()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestClock_AfterFuncContext(t *testing.T) {
+	var (
+		clk         = clock.NewMonotonicClock()
+		called      = make(chan struct{})
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+
+	clk.AfterFuncContext(context.Background(), time.Millisecond, func() {
+		close(called)
+	})
+	requireRecv(t, called)
+
+	clk.AfterFuncContext(ctx, 10*time.Millisecond, func() {
+		require.Fail(t, "func called after context was canceled")
+	})
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestClock_Timer_StopAndDrain(t *testing.T) {
+	timer := clock.NewMonotonicClock().NewTimer(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	// Depending on the Go version's timer semantics, Stop may report that an
+	// unreceived tick was stopped, so only the channel is checked.
+	timer.StopAndDrain()
+	requireNoTick(t, timer.C)
+}
+
+func TestClock_Timer_Active(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk    = newTestClock(t, tt.opts...)
+				timer  = clk.NewTimer(time.Millisecond)
+				called = make(chan struct{})
+				funcs  = clk.AfterFunc(time.Millisecond, func() {
+					close(called)
+				})
+			)
+
+			requireTick(t, timer.C)
+			require.False(t, timer.Active())
+			timer.Reset(time.Hour)
+			require.True(t, timer.Active())
+			timer.Stop()
+			require.False(t, timer.Active())
+
+			<-called
+			require.False(t, funcs.Active())
+			funcs.Reset(time.Hour)
+			require.True(t, funcs.Active())
+			funcs.Stop()
+			require.False(t, funcs.Active())
+		})
+	}
+}
+
 func TestClock_NewTicker(t *testing.T) {
 	cases := map[string]struct {
 		opts []clock.Option
@@ -212,6 +558,123 @@ func TestClock_NewTicker(t *testing.T) {
 			ticker.Stop()
 			ticker.Reset(time.Millisecond)
 			requireTick(t, ticker.C)
+
+			require.True(t, ticker.ResetActive(time.Millisecond))
+			ticker.Stop()
+			require.False(t, ticker.ResetActive(time.Millisecond))
+			requireTick(t, ticker.C)
+		})
+	}
+}
+
+func TestClock_NewAlignedTicker(t *testing.T) {
+	const period = time.Hour
+
+	// The clock's time is a millisecond before a boundary, so the first tick
+	// is due almost immediately while the next is a full period away.
+	var (
+		fake = clock.NewFakeClock()
+		clk  = newTestClock(t, clock.WithTimeFunc(fake.Now))
+	)
+	fake.SetNanotime(int64(5*period - time.Millisecond))
+
+	ticker := clk.NewAlignedTicker(period)
+	defer ticker.Stop()
+
+	requireTick(t, ticker.C)
+	requireNoTick(t, ticker.C)
+
+	ticker.Stop()
+	require.False(t, ticker.ResetActive(time.Millisecond))
+	requireTick(t, ticker.C)
+
+	// Stopping an aligned ticker before its first tick prevents any ticks.
+	pending := clk.NewAlignedTicker(time.Hour)
+	pending.Stop()
+	requireNoTick(t, pending.C)
+
+	require.Panics(t, func() {
+		clk.NewAlignedTicker(0)
+	})
+}
+
+func TestNewManualTicker(t *testing.T) {
+	var (
+		clk             = clock.NewFakeClock()
+		ticker, trigger = clock.NewManualTicker(clk)
+	)
+	defer ticker.Stop()
+
+	// Time elapsing does not cause ticks.
+	clk.Add(time.Hour)
+	requireNoTick(t, ticker.C)
+
+	// Each trigger sends the clock's current time.
+	trigger()
+	require.Equal(t, clk.Now(), requireTick(t, ticker.C))
+	requireNoTick(t, ticker.C)
+
+	// Ticks are dropped if the channel is full.
+	trigger()
+	clk.Add(time.Second)
+	trigger()
+	require.Equal(t, clk.Now().Add(-time.Second), requireTick(t, ticker.C))
+	requireNoTick(t, ticker.C)
+	require.EqualValues(t, 1, ticker.Missed())
+
+	// Triggering a stopped ticker has no effect until it is reset.
+	ticker.Stop()
+	trigger()
+	requireNoTick(t, ticker.C)
+	require.False(t, ticker.ResetActive(time.Second))
+	require.True(t, ticker.ResetActive(time.Second))
+	trigger()
+	requireTick(t, ticker.C)
+
+	require.Panics(t, func() {
+		ticker.Reset(0)
+	})
+}
+
+func TestClock_NewTickerFunc(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk    = newTestClock(t, tt.opts...)
+				calls  = atomic.NewInt64(0)
+				ticker = clk.NewTickerFunc(time.Millisecond, func() {
+					calls.Inc()
+				})
+			)
+			defer ticker.Stop()
+
+			waitFor(t, time.Second, func() bool {
+				return calls.Load() >= 3
+			})
+
+			// Stopping the ticker stops further calls.
+			ticker.Stop()
+			time.Sleep(10 * time.Millisecond)
+			stopped := calls.Load()
+			time.Sleep(10 * time.Millisecond)
+			require.Equal(t, stopped, calls.Load())
+
+			// Resetting the ticker resumes calls.
+			ticker.Reset(time.Millisecond)
+			waitFor(t, time.Second, func() bool {
+				return calls.Load() >= stopped+3
+			})
 		})
 	}
 }
@@ -247,6 +710,65 @@ func TestClock_Since(t *testing.T) {
 	}
 }
 
+func TestClock_Snapshot(t *testing.T) {
+	var (
+		reads  atomic.Int64
+		nsFn   = func() int64 { return reads.Inc() * int64(time.Second) }
+		timeFn = func() time.Time { return time.Unix(0, nsFn()) }
+		loc    = time.FixedZone("test", -7*60*60)
+		cases  = map[string]struct {
+			clk     clock.Clock
+			wantLoc *time.Location
+			reads   int64
+		}{
+			"nanotime func": {
+				clk:     newTestClock(t, clock.WithNanotimeFunc(nsFn)),
+				wantLoc: time.Local,
+				reads:   1,
+			},
+			"time func": {
+				clk: newTestClock(
+					t,
+					clock.WithTimeFunc(timeFn),
+					clock.WithLocation(loc),
+				),
+				wantLoc: loc,
+				reads:   1,
+			},
+			"offset": {
+				clk: clock.WithOffset(
+					newTestClock(t, clock.WithNanotimeFunc(nsFn)),
+					time.Hour,
+				),
+				wantLoc: time.Local,
+				reads:   1,
+			},
+			"stepping": {
+				clk:     clock.NewSteppingClock(123, time.Second),
+				wantLoc: time.Local,
+			},
+			"fake": {
+				clk:     clock.NewFakeClock(clock.WithFakeLocation(loc)),
+				wantLoc: loc,
+			},
+			"frozen": {
+				clk:     clock.NewFrozenClock(time.Unix(123, 0)),
+				wantLoc: time.Local,
+			},
+		}
+	)
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			before := reads.Load()
+			now, ns := tt.clk.Snapshot()
+			require.Equal(t, tt.reads, reads.Load()-before)
+			require.Equal(t, ns, now.UnixNano())
+			require.Equal(t, tt.wantLoc, now.Location())
+		})
+	}
+}
+
 func TestClock_After(t *testing.T) {
 	cases := map[string]struct {
 		opts []clock.Option
@@ -297,6 +819,182 @@ func TestClock_Tick(t *testing.T) {
 	}
 }
 
+func TestClock_TickContext(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk         = newTestClock(t, tt.opts...)
+				ctx, cancel = context.WithCancel(context.Background())
+				tickerC     = clk.TickContext(ctx, time.Millisecond)
+			)
+
+			for i := 0; i < 10; i++ {
+				requireTick(t, tickerC)
+			}
+
+			cancel()
+			requireClosed(t, tickerC)
+		})
+	}
+}
+
+func TestClock_DeadlineContext(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk         = newTestClock(t, tt.opts...)
+				ctx, cancel = clk.DeadlineContext(
+					context.Background(),
+					clk.Nanotime()+int64(10*time.Millisecond),
+				)
+			)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.WithinDuration(
+				t,
+				time.Now().Add(10*time.Millisecond),
+				deadline,
+				10*time.Millisecond,
+			)
+
+			requireContextDone(t, ctx)
+			require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestClock_TimeoutContext(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk         = newTestClock(t, tt.opts...)
+				ctx, cancel = clk.TimeoutContext(
+					context.Background(),
+					10*time.Millisecond,
+				)
+			)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.WithinDuration(
+				t,
+				time.Now().Add(10*time.Millisecond),
+				deadline,
+				10*time.Millisecond,
+			)
+
+			requireContextDone(t, ctx)
+			require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestRemainingUntilDeadline(t *testing.T) {
+	// Contexts without deadlines report nothing.
+	d, ok := clock.RemainingUntilDeadline(context.Background(), clock.NewWallClock())
+	require.False(t, ok)
+	require.Zero(t, d)
+
+	// Real deadlines are measured against wall clocks.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	d, ok = clock.RemainingUntilDeadline(ctx, clock.NewWallClock())
+	require.True(t, ok)
+	require.InDelta(t, time.Hour, d, float64(time.Second))
+
+	// Fake deadlines are measured against fake clocks.
+	fake := clock.NewFakeClock()
+	ctx, cancel = fake.TimeoutContext(context.Background(), time.Minute)
+	defer cancel()
+	d, ok = clock.RemainingUntilDeadline(ctx, fake)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, d)
+
+	fake.Add(time.Minute + time.Second)
+	d, ok = clock.RemainingUntilDeadline(ctx, fake)
+	require.True(t, ok)
+	require.Equal(t, -time.Second, d)
+}
+
+func TestWait(t *testing.T) {
+	t.Run("elapsed", func(t *testing.T) {
+		var (
+			clk  = clock.NewFakeClock()
+			errs = make(chan error)
+		)
+
+		go func() {
+			errs <- clock.Wait(context.Background(), clk, time.Second)
+		}()
+
+		clk.BlockUntil(1)
+		clk.Add(time.Second)
+		require.NoError(t, <-errs)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		var (
+			clk         = clock.NewFakeClock()
+			ctx, cancel = context.WithCancel(context.Background())
+			errs        = make(chan error)
+		)
+		defer cancel()
+
+		go func() {
+			errs <- clock.Wait(ctx, clk, time.Second)
+		}()
+
+		clk.BlockUntil(1)
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+
+		// A done context takes precedence, even for non-positive durations.
+		require.ErrorIs(t, clock.Wait(ctx, clk, 0), context.Canceled)
+	})
+
+	t.Run("non-positive", func(t *testing.T) {
+		clk := clock.NewFakeClock()
+		require.NoError(t, clock.Wait(context.Background(), clk, 0))
+		require.NoError(t, clock.Wait(context.Background(), clk, -time.Second))
+	})
+}
+
 func TestClock_Sleep(t *testing.T) {
 	cases := map[string]struct {
 		name string
@@ -339,6 +1037,39 @@ func TestClock_Sleep(t *testing.T) {
 	}
 }
 
+func TestClock_SleepUntil(t *testing.T) {
+	cases := map[string]struct {
+		opts []clock.Option
+	}{
+		"nanotime func": {
+			opts: []clock.Option{_withNanotimeFunc},
+		},
+		"time func": {
+			opts: []clock.Option{_withTimeFunc},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			clk := newTestClock(t, tt.opts...)
+
+			start := clk.Nanotime()
+			clk.SleepUntil(clk.Now().Add(50 * time.Millisecond))
+			require.GreaterOrEqual(t, clk.SinceNanotime(start), 50*time.Millisecond)
+
+			start = clk.Nanotime()
+			clk.SleepUntilNanotime(start + int64(50*time.Millisecond))
+			require.GreaterOrEqual(t, clk.SinceNanotime(start), 50*time.Millisecond)
+
+			// Targets in the past return immediately.
+			start = clk.Nanotime()
+			clk.SleepUntil(time.Unix(0, 0))
+			clk.SleepUntilNanotime(0)
+			require.Less(t, clk.SinceNanotime(start), 50*time.Millisecond)
+		})
+	}
+}
+
 func TestClock_Stopwatch(t *testing.T) {
 	var (
 		cases = map[string]struct {
@@ -379,6 +1110,14 @@ func TestClock_Stopwatch(t *testing.T) {
 	}
 }
 
+func requireRecv(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for receive")
+	}
+}
+
 func newTestClock(t *testing.T, opts ...clock.Option) clock.Clock {
 	clk, err := clock.NewClock(opts...)
 	require.NoError(t, err)
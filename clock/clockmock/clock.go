@@ -5,6 +5,7 @@
 package clockmock
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -63,6 +64,33 @@ func (mr *MockClockMockRecorder) AfterFunc(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterFunc", reflect.TypeOf((*MockClock)(nil).AfterFunc), arg0, arg1)
 }
 
+// AfterFuncContext mocks base method.
+func (m *MockClock) AfterFuncContext(arg0 context.Context, arg1 time.Duration, arg2 func()) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AfterFuncContext", arg0, arg1, arg2)
+}
+
+// AfterFuncContext indicates an expected call of AfterFuncContext.
+func (mr *MockClockMockRecorder) AfterFuncContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterFuncContext", reflect.TypeOf((*MockClock)(nil).AfterFuncContext), arg0, arg1, arg2)
+}
+
+// DeadlineContext mocks base method.
+func (m *MockClock) DeadlineContext(arg0 context.Context, arg1 int64) (context.Context, context.CancelFunc) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadlineContext", arg0, arg1)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(context.CancelFunc)
+	return ret0, ret1
+}
+
+// DeadlineContext indicates an expected call of DeadlineContext.
+func (mr *MockClockMockRecorder) DeadlineContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadlineContext", reflect.TypeOf((*MockClock)(nil).DeadlineContext), arg0, arg1)
+}
+
 // Nanotime mocks base method.
 func (m *MockClock) Nanotime() int64 {
 	m.ctrl.T.Helper()
@@ -77,18 +105,36 @@ func (mr *MockClockMockRecorder) Nanotime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nanotime", reflect.TypeOf((*MockClock)(nil).Nanotime))
 }
 
+// NewAlignedTicker mocks base method.
+func (m *MockClock) NewAlignedTicker(arg0 time.Duration) *clock.Ticker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAlignedTicker", arg0)
+	ret0, _ := ret[0].(*clock.Ticker)
+	return ret0
+}
+
+// NewAlignedTicker indicates an expected call of NewAlignedTicker.
+func (mr *MockClockMockRecorder) NewAlignedTicker(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAlignedTicker", reflect.TypeOf((*MockClock)(nil).NewAlignedTicker), arg0)
+}
+
 // NewStopwatch mocks base method.
-func (m *MockClock) NewStopwatch() *clock.Stopwatch {
+func (m *MockClock) NewStopwatch(arg0 ...clock.StopwatchOption) *clock.Stopwatch {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewStopwatch")
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NewStopwatch", varargs...)
 	ret0, _ := ret[0].(*clock.Stopwatch)
 	return ret0
 }
 
 // NewStopwatch indicates an expected call of NewStopwatch.
-func (mr *MockClockMockRecorder) NewStopwatch() *gomock.Call {
+func (mr *MockClockMockRecorder) NewStopwatch(arg0 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStopwatch", reflect.TypeOf((*MockClock)(nil).NewStopwatch))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStopwatch", reflect.TypeOf((*MockClock)(nil).NewStopwatch), arg0...)
 }
 
 // NewTicker mocks base method.
@@ -105,6 +151,20 @@ func (mr *MockClockMockRecorder) NewTicker(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTicker", reflect.TypeOf((*MockClock)(nil).NewTicker), arg0)
 }
 
+// NewTickerFunc mocks base method.
+func (m *MockClock) NewTickerFunc(arg0 time.Duration, arg1 func()) *clock.Ticker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTickerFunc", arg0, arg1)
+	ret0, _ := ret[0].(*clock.Ticker)
+	return ret0
+}
+
+// NewTickerFunc indicates an expected call of NewTickerFunc.
+func (mr *MockClockMockRecorder) NewTickerFunc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTickerFunc", reflect.TypeOf((*MockClock)(nil).NewTickerFunc), arg0, arg1)
+}
+
 // NewTimer mocks base method.
 func (m *MockClock) NewTimer(arg0 time.Duration) *clock.Timer {
 	m.ctrl.T.Helper()
@@ -173,6 +233,45 @@ func (mr *MockClockMockRecorder) Sleep(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sleep", reflect.TypeOf((*MockClock)(nil).Sleep), arg0)
 }
 
+// SleepUntil mocks base method.
+func (m *MockClock) SleepUntil(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SleepUntil", arg0)
+}
+
+// SleepUntil indicates an expected call of SleepUntil.
+func (mr *MockClockMockRecorder) SleepUntil(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SleepUntil", reflect.TypeOf((*MockClock)(nil).SleepUntil), arg0)
+}
+
+// SleepUntilNanotime mocks base method.
+func (m *MockClock) SleepUntilNanotime(arg0 int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SleepUntilNanotime", arg0)
+}
+
+// SleepUntilNanotime indicates an expected call of SleepUntilNanotime.
+func (mr *MockClockMockRecorder) SleepUntilNanotime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SleepUntilNanotime", reflect.TypeOf((*MockClock)(nil).SleepUntilNanotime), arg0)
+}
+
+// Snapshot mocks base method.
+func (m *MockClock) Snapshot() (time.Time, int64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(int64)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockClockMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockClock)(nil).Snapshot))
+}
+
 // Tick mocks base method.
 func (m *MockClock) Tick(arg0 time.Duration) <-chan time.Time {
 	m.ctrl.T.Helper()
@@ -186,3 +285,32 @@ func (mr *MockClockMockRecorder) Tick(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockClock)(nil).Tick), arg0)
 }
+
+// TickContext mocks base method.
+func (m *MockClock) TickContext(arg0 context.Context, arg1 time.Duration) <-chan time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TickContext", arg0, arg1)
+	ret0, _ := ret[0].(<-chan time.Time)
+	return ret0
+}
+
+// TickContext indicates an expected call of TickContext.
+func (mr *MockClockMockRecorder) TickContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TickContext", reflect.TypeOf((*MockClock)(nil).TickContext), arg0, arg1)
+}
+
+// TimeoutContext mocks base method.
+func (m *MockClock) TimeoutContext(arg0 context.Context, arg1 time.Duration) (context.Context, context.CancelFunc) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutContext", arg0, arg1)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(context.CancelFunc)
+	return ret0, ret1
+}
+
+// TimeoutContext indicates an expected call of TimeoutContext.
+func (mr *MockClockMockRecorder) TimeoutContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutContext", reflect.TypeOf((*MockClock)(nil).TimeoutContext), arg0, arg1)
+}
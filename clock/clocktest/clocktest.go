@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package clocktest provides helpers for using clocks in tests.
+package clocktest
+
+import (
+	"testing"
+
+	"go.mway.dev/chrono/clock"
+)
+
+// NewFakeClock creates a new [clock.FakeClock] configured by the given options,
+// and registers a cleanup function with tb that tears the clock down once the
+// test and its subtests have completed: it waits for any callbacks that the
+// clock has started to return (see [clock.FakeClock.FlushCallbacks]), and then
+// closes the clock (see [clock.FakeClock.Close]), which discards all pending
+// timers and tickers without firing them and wakes any goroutine that is
+// blocked sleeping on the clock. The clock's time is unchanged.
+//
+// Cleanup functions run in last-in, first-out order, so resources that use the
+// clock and are cleaned up via tb.Cleanup after NewFakeClock is called, such as
+// periodic handles, are torn down before the clock. As with
+// [clock.FakeClock.FlushCallbacks], a callback that never returns causes the
+// cleanup to block.
+func NewFakeClock(tb testing.TB, opts ...clock.FakeOption) *clock.FakeClock {
+	tb.Helper()
+
+	clk := clock.NewFakeClock(opts...)
+	tb.Cleanup(func() {
+		clk.FlushCallbacks()
+		clk.Close()
+	})
+	return clk
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clocktest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/clock/clocktest"
+	"go.uber.org/atomic"
+)
+
+func TestNewFakeClock(t *testing.T) {
+	var (
+		clk       *clock.FakeClock
+		timer     *clock.Timer
+		called    atomic.Bool
+		sleepdone = make(chan struct{})
+	)
+
+	t.Run("test", func(t *testing.T) {
+		clk = clocktest.NewFakeClock(t, clock.WithFakeLocation(time.UTC))
+		require.Equal(t, time.UTC, clk.Now().Location())
+
+		timer = clk.NewTimer(2 * time.Second)
+		clk.AfterFunc(time.Second, func() {
+			time.Sleep(10 * time.Millisecond)
+			called.Store(true)
+		})
+		go func() {
+			defer close(sleepdone)
+			clk.Sleep(time.Hour)
+		}()
+
+		clk.BlockUntil(3)
+		clk.Add(time.Second)
+	})
+
+	// Started callbacks have returned, pending timers were discarded without
+	// firing, and sleepers were woken.
+	require.True(t, called.Load())
+	require.EqualValues(t, time.Second, clk.Nanotime())
+	clk.Add(time.Second)
+
+	select {
+	case <-timer.C:
+		require.Fail(t, "unexpected tick")
+	default:
+	}
+
+	select {
+	case <-sleepdone:
+	case <-time.After(time.Second):
+		require.Fail(t, "sleep did not wake")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Wait blocks until d has elapsed according to clk, in which case it returns
+// nil, or until ctx is done, in which case it returns ctx.Err(). If ctx is
+// already done, Wait returns ctx.Err() immediately; otherwise, if d <= 0, Wait
+// returns nil immediately.
+func Wait(ctx context.Context, clk Clock, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	timer := clk.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RemainingUntilDeadline returns the time remaining until ctx's deadline, as
+// measured against clk's Now, and whether ctx has a deadline. If the deadline
+// has passed, the returned duration is negative. If ctx has no deadline,
+// RemainingUntilDeadline returns 0 and false.
+//
+// If ctx has a deadline in fake time (see [FakeDeadline]), that deadline is
+// used, and clk should be the [FakeClock] that created it. Otherwise, ctx's
+// real deadline is used, which is in wall time: it is comparable with wall
+// clocks, but not with monotonic clocks, whose Now is not wall time.
+func RemainingUntilDeadline(ctx context.Context, clk Clock) (time.Duration, bool) {
+	deadline, ok := FakeDeadline(ctx)
+	if !ok {
+		deadline, ok = ctx.Deadline()
+	}
+	if !ok {
+		return 0, false
+	}
+	return deadline.Sub(clk.Now()), true
+}
+
+// FakeDeadline returns the deadline, in fake time, of ctx or of the nearest of
+// its parents that was created by a [FakeClock]'s DeadlineContext or
+// TimeoutContext method, and whether there is such a deadline. The Deadline
+// method of such contexts only reports real deadlines inherited from their
+// parents, since a fake deadline would be misinterpreted as real time by the
+// standard library and by I/O deadlines derived from it.
+func FakeDeadline(ctx context.Context) (time.Time, bool) {
+	if fake, ok := ctx.Value(fakeDeadlineKey{}).(*fakeDeadlineContext); ok {
+		return fake.deadline, true
+	}
+	return time.Time{}, false
+}
+
+// afterFuncContext uses schedule to call fn after d, unless ctx is done first,
+// in which case the timer returned by schedule is stopped. Exactly one of fn
+// and the timer's cancellation takes effect.
+func afterFuncContext(
+	ctx context.Context,
+	d time.Duration,
+	fn func(),
+	schedule func(time.Duration, func()) *Timer,
+) {
+	var (
+		timer *Timer
+		ready = make(chan struct{})
+		stop  = context.AfterFunc(ctx, func() {
+			<-ready
+			timer.Stop()
+		})
+	)
+
+	timer = schedule(d, func() {
+		// If the context's callback has already started, ctx won.
+		if stop() {
+			fn()
+		}
+	})
+	close(ready)
+}
+
+// runtimeDeadlineContext returns a context that is canceled once d has
+// elapsed according to Go's runtime timers.
+func runtimeDeadlineContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(parent, time.Now().Add(d))
+}
+
+// fakeDeadlineKey is the context key under which a fakeDeadlineContext
+// reports itself.
+type fakeDeadlineKey struct{}
+
+// A fakeDeadlineContext is a [context.Context] whose deadline is measured by a
+// [FakeClock] rather than by Go's runtime timers. Its Deadline method is that
+// of its parent; see [FakeDeadline].
+type fakeDeadlineContext struct {
+	context.Context // parent
+
+	done       chan struct{}
+	deadline   time.Time
+	err        error
+	timer      *Timer
+	stopParent func() bool
+	mu         sync.Mutex
+}
+
+func newFakeDeadlineContext(
+	clk *FakeClock,
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	// An earlier fake deadline of the parent takes precedence.
+	if deadline, ok := FakeDeadline(parent); ok {
+		ns = min(ns, deadline.UnixNano())
+	}
+
+	ctx := &fakeDeadlineContext{
+		Context:  parent,
+		done:     make(chan struct{}),
+		deadline: time.Unix(0, ns),
+	}
+
+	// Hold the lock until setup is complete, in case the parent is already
+	// done or the deadline is reached concurrently.
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	ctx.stopParent = context.AfterFunc(parent, func() {
+		ctx.cancelWithErr(parent.Err())
+	})
+
+	d := time.Duration(ns - clk.Nanotime())
+	if d <= 0 {
+		ctx.cancelWithErrNosync(context.DeadlineExceeded)
+	} else {
+		ctx.timer = clk.AfterFunc(d, func() {
+			ctx.cancelWithErr(context.DeadlineExceeded)
+		})
+	}
+
+	return ctx, func() {
+		ctx.cancelWithErr(context.Canceled)
+	}
+}
+
+func (c *fakeDeadlineContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *fakeDeadlineContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *fakeDeadlineContext) Value(key any) any {
+	if key == (fakeDeadlineKey{}) {
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+func (c *fakeDeadlineContext) cancelWithErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelWithErrNosync(err)
+}
+
+func (c *fakeDeadlineContext) cancelWithErrNosync(err error) {
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	close(c.done)
+
+	c.stopParent()
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+}
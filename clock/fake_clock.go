@@ -21,6 +21,7 @@
 package clock
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
@@ -34,26 +35,81 @@ var _ Clock = (*FakeClock)(nil)
 // in tests. It does not keep time by itself: use [FakeClock.Add],
 // [FakeClock.SetTime], and related functions to manage the clock's time.
 type FakeClock struct {
-	clk    monotonicClock
-	timers []*fakeTimer
-	now    atomic.Int64
-	mu     sync.Mutex
+	clk     monotonicClock
+	timers  []*fakeTimer
+	shared  *fakeTime
+	options FakeOptions
+	mu      sync.Mutex
+	cond    *sync.Cond
+	drift   float64
+	synced  bool
+	fifo    *callbackQueue
+	pending int
+	done    chan struct{} // closed by Close
+	closed  bool
+}
+
+// NewFakeClock creates a new [FakeClock] configured by the given options.
+func NewFakeClock(opts ...FakeOption) *FakeClock {
+	return newFakeClock(DefaultFakeOptions().With(opts...), &fakeTime{})
 }
 
-// NewFakeClock creates a new [FakeClock].
-func NewFakeClock() *FakeClock {
-	c := &FakeClock{}
+func newFakeClock(options FakeOptions, shared *fakeTime) *FakeClock {
+	c := &FakeClock{
+		shared:  shared,
+		options: options,
+		drift:   options.Drift,
+		synced:  options.SynchronousCallbacks,
+		done:    make(chan struct{}),
+	}
+	if options.FIFOCallbacks {
+		c.fifo = &callbackQueue{}
+	}
+	c.cond = sync.NewCond(&c.mu)
 	c.clk = monotonicClock{
-		fn: func() int64 {
-			return c.now.Load()
-		},
+		fn: shared.now.Load,
 	}
+	shared.join(c)
 	return c
 }
 
-// Add adds d to the clock's internal time.
+// Add adds d to the clock's internal time. If the clock was created with
+// [WithFakeDrift], d is adjusted by the configured drift first.
 func (c *FakeClock) Add(d time.Duration) {
-	c.checkTimers(c.now.Add(int64(d)))
+	if c.drift != 0 {
+		d = time.Duration(float64(d) * (1 + c.drift))
+	}
+
+	c.shared.checkTimers(c.shared.now.Add(int64(d)))
+}
+
+// Advance is an alias for [FakeClock.Add].
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Add(d)
+}
+
+// AdvanceBy adds each of steps to the clock's internal time in order, firing
+// any timers that are due after each step. Unlike a single call to
+// [FakeClock.Add] with the sum of steps, which fires each due timer at most
+// once, this allows tickers to fire once per step and the order in which
+// timers fire to be observed between steps.
+func (c *FakeClock) AdvanceBy(steps ...time.Duration) {
+	for _, d := range steps {
+		c.Add(d)
+	}
+}
+
+// Fork returns a new [FakeClock], configured with the same options as c, that
+// shares c's time but has its own set of timers and tickers, e.g. to simulate
+// independent subsystems on the same machine. The time may be advanced via
+// either clock, or via any other fork of either clock, which fires the due
+// timers of every clock sharing the time. Calling [FakeClock.Reset] on any of
+// them resets the shared time, but only discards the timers of the clock that
+// it was called on. Forks remain attached to the shared time until they are
+// closed via [FakeClock.Close], so forks that are no longer needed should be
+// closed.
+func (c *FakeClock) Fork() *FakeClock {
+	return newFakeClock(c.options, c.shared)
 }
 
 // After returns a channel that receives the current time after d has elapsed.
@@ -71,6 +127,25 @@ func (c *FakeClock) AfterFunc(d time.Duration, fn func()) *Timer {
 	}
 }
 
+// AfterFuncContext is like [FakeClock.AfterFunc], but fn is not called if ctx
+// is done before the clock has advanced by d, in which case the underlying
+// timer is stopped.
+func (c *FakeClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+// DeadlineContext returns a copy of parent that is canceled once the clock's
+// internal time reaches ns, e.g. via [FakeClock.Add], rather than once ns is
+// reached in real time. The context's Deadline method reports only deadlines
+// inherited from parent; use [FakeDeadline] to obtain the fake deadline. See
+// [Clock.DeadlineContext] for more information.
+func (c *FakeClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return newFakeDeadlineContext(c, parent, ns)
+}
+
 // Nanotime returns the clock's internal time as integer nanoseconds.
 func (c *FakeClock) Nanotime() int64 {
 	return c.clk.Nanotime()
@@ -83,7 +158,43 @@ func (c *FakeClock) NewTicker(d time.Duration) *Ticker {
 		panic("non-positive interval for FakeClock.NewTicker")
 	}
 
-	x := c.addTicker(d)
+	x := c.addTicker(d, d, nil)
+	return &Ticker{
+		C:    x.ch,
+		fake: x,
+	}
+}
+
+// NewAlignedTicker returns a new [Ticker] that receives time ticks at every
+// multiple of d of the clock's internal time, starting with the first multiple
+// after the current time. If d is not greater than zero, [NewAlignedTicker]
+// will panic.
+func (c *FakeClock) NewAlignedTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewAlignedTicker")
+	}
+
+	return c.newTickerAfter(untilBoundary(c.Nanotime(), d), d)
+}
+
+// newTickerAfter returns a new [Ticker] that first ticks after first, and then
+// every d thereafter.
+func (c *FakeClock) newTickerAfter(first time.Duration, d time.Duration) *Ticker {
+	x := c.addTicker(first, d, nil)
+	return &Ticker{
+		C:    x.ch,
+		fake: x,
+	}
+}
+
+// NewTickerFunc returns a new [Ticker] that calls fn in its own goroutine every
+// d. If d is not greater than zero, [NewTickerFunc] will panic.
+func (c *FakeClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTickerFunc")
+	}
+
+	x := c.addTicker(d, d, fn)
 	return &Ticker{
 		C:    x.ch,
 		fake: x,
@@ -99,11 +210,63 @@ func (c *FakeClock) NewTimer(d time.Duration) *Timer {
 	}
 }
 
-// Now returns the clock's internal time as a [time.Time].
+// Now returns the clock's internal time as a [time.Time], in the location
+// configured by [WithFakeLocation], if any, or in local time otherwise.
 func (c *FakeClock) Now() time.Time {
+	if loc := c.options.Location; loc != nil {
+		return c.clk.Now().In(loc)
+	}
 	return c.clk.Now()
 }
 
+// Reset sets the clock's time back to zero and discards all pending timers
+// and tickers without firing them, leaving the clock as if it were newly
+// created. Any goroutine that is blocked on a discarded timer or ticker,
+// including a call to [FakeClock.Sleep], will never be woken, so callers
+// should tear down such goroutines before calling Reset. Discarded timers and
+// tickers may be rescheduled by calling their Reset methods.
+func (c *FakeClock) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.discardTimersNosync()
+	c.shared.now.Store(0)
+}
+
+// Close tears down the clock: it discards all pending timers and tickers
+// without firing them, so that they can be garbage collected, and wakes any
+// goroutine that is blocked in [FakeClock.Sleep] or [FakeClock.SleepUntil], as
+// will any later call to either. The clock's time is unchanged. Goroutines
+// that are blocked receiving from a timer's or ticker's channel are not woken,
+// since no tick is due; they should be torn down by other means, e.g. by
+// canceling a context. Close does not wait for callbacks that the clock has
+// already started (see [FakeClock.FlushCallbacks]). Calling Close more than
+// once has no further effect.
+//
+// A closed clock is detached from any other clocks with which it shares time
+// (see [FakeClock.Fork]): it still reports the shared time, but advancing the
+// time no longer visits it, so timers created after Close never fire.
+func (c *FakeClock) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.done)
+	c.discardTimersNosync()
+	c.mu.Unlock()
+
+	c.shared.leave(c)
+}
+
+func (c *FakeClock) discardTimersNosync() {
+	for i := range c.timers {
+		c.timers[i] = nil
+	}
+	c.timers = c.timers[:0]
+}
+
 // SetTime sets the clock's time to t.
 func (c *FakeClock) SetTime(t time.Time) {
 	c.SetNanotime(t.UnixNano())
@@ -111,8 +274,8 @@ func (c *FakeClock) SetTime(t time.Time) {
 
 // SetNanotime sets the clock's time to ns.
 func (c *FakeClock) SetNanotime(ns int64) {
-	c.now.Store(ns)
-	c.checkTimers(ns)
+	c.shared.now.Store(ns)
+	c.shared.checkTimers(ns)
 }
 
 // Since returns the amount of time that elapsed between the clock's internal
@@ -127,20 +290,92 @@ func (c *FakeClock) SinceNanotime(ns int64) time.Duration {
 	return time.Duration(c.Nanotime() - ns)
 }
 
-// Sleep blocks for d.
+// Sleep blocks for d, or until the clock is closed (see [FakeClock.Close]).
 //
 // Note that Sleep must be called from a different goroutine than the clock's
 // time is being managed on, or the program will deadlock.
 func (c *FakeClock) Sleep(d time.Duration) {
 	timer := c.addTimer(d, nil)
 	defer c.removeTimer(timer)
-	<-timer.ch
+
+	select {
+	case <-timer.ch:
+	case <-c.done:
+	}
+}
+
+// SleepUntil blocks until the clock's time reaches t, returning immediately if
+// it already has. Like [FakeClock.Sleep], SleepUntil must be called from a
+// different goroutine than the clock's time is being managed on.
+func (c *FakeClock) SleepUntil(t time.Time) {
+	c.SleepUntilNanotime(t.UnixNano())
+}
+
+// SleepUntilNanotime is like [FakeClock.SleepUntil], but the target time is
+// given as integer nanoseconds.
+func (c *FakeClock) SleepUntilNanotime(ns int64) {
+	timer := c.addTimerAt(ns)
+	if timer == nil {
+		return
+	}
+	defer c.removeTimer(timer)
+
+	select {
+	case <-timer.ch:
+	case <-c.done:
+	}
+}
+
+// Snapshot returns the clock's internal time both as a [time.Time], in the
+// same location as [FakeClock.Now], and as integer nanoseconds, derived from a
+// single reading of the clock.
+func (c *FakeClock) Snapshot() (time.Time, int64) {
+	ns := c.Nanotime()
+	now := time.Unix(0, ns)
+	if loc := c.options.Location; loc != nil {
+		now = now.In(loc)
+	}
+	return now, ns
+}
+
+// BlockUntil blocks until at least n timers and tickers are scheduled on the
+// clock. This includes timers created internally, e.g. by [FakeClock.Sleep].
+// It is useful for waiting until goroutines under test have set up their
+// timers before advancing the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// FlushCallbacks blocks until every callback that the clock has started, e.g.
+// for timers created by [FakeClock.AfterFunc] that fired during a previous call
+// to [FakeClock.Add], has returned. Callbacks that schedule new timers do not
+// cause FlushCallbacks to wait for those timers unless they fire (and start
+// their own callbacks) before FlushCallbacks returns. Calling FlushCallbacks
+// from within a callback deadlocks.
+//
+// Callbacks of clocks created with [WithSynchronousCallbacks] have always
+// returned by the time that the clock has finished advancing, so
+// FlushCallbacks returns immediately for such clocks. Each [FakeClock.Fork]
+// tracks its own callbacks.
+func (c *FakeClock) FlushCallbacks() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.pending > 0 {
+		c.cond.Wait()
+	}
 }
 
 // NewStopwatch returns a new [Stopwatch] that uses the current clock for
-// measuring time. The clock's current time is used as the stopwatch's epoch.
-func (c *FakeClock) NewStopwatch() *Stopwatch {
-	return newStopwatch(c)
+// measuring time, configured by the given options. Unless the stopwatch is
+// created stopped, the clock's current time is used as its epoch.
+func (c *FakeClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
 }
 
 // Tick returns a new channel that receives time ticks every d. It is
@@ -153,14 +388,35 @@ func (c *FakeClock) Tick(d time.Duration) <-chan time.Time {
 	return c.NewTicker(d).C
 }
 
-func (c *FakeClock) addTicker(d time.Duration) *fakeTimer {
-	fake := newFakeTicker(c, d)
+// TickContext is like [FakeClock.Tick], but the underlying ticker is stopped
+// and the returned channel is closed once ctx is done.
+func (c *FakeClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return tickContext(ctx, c.NewTicker(d))
+}
+
+// TimeoutContext returns a copy of parent that is canceled once the clock has
+// advanced by d from its current time. It is equivalent to calling
+// [FakeClock.DeadlineContext] with c.Nanotime()+d.
+func (c *FakeClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return c.DeadlineContext(parent, c.Nanotime()+int64(d))
+}
+
+func (c *FakeClock) addTicker(
+	first time.Duration,
+	d time.Duration,
+	fn func(),
+) *fakeTimer {
+	fake := newFakeTicker(c, first, d, fn)
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.timers = append(c.timers, fake)
 	c.sortTimersNosync()
+	c.cond.Broadcast()
 
 	return fake
 }
@@ -173,32 +429,123 @@ func (c *FakeClock) addTimer(d time.Duration, fn func()) *fakeTimer {
 
 	c.timers = append(c.timers, fake)
 	c.sortTimersNosync()
+	c.cond.Broadcast()
+
+	return fake
+}
+
+// addTimerAt adds a timer that is due once the clock's time reaches ns, or
+// returns nil if it already has. The clock's time is read while holding its
+// lock, so advancing the clock concurrently cannot cause the timer to be
+// missed or to be due later than ns.
+func (c *FakeClock) addTimerAt(ns int64) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ns <= c.Nanotime() {
+		return nil
+	}
+
+	fake := &fakeTimer{
+		clk:  c,
+		ch:   make(chan time.Time, 1),
+		when: ns,
+	}
+	c.timers = append(c.timers, fake)
+	c.sortTimersNosync()
+	c.cond.Broadcast()
 
 	return fake
 }
 
 func (c *FakeClock) checkTimers(now int64) {
+	callbacks := c.fireTimers(now)
+
+	if c.synced {
+		for _, fn := range callbacks {
+			fn()
+		}
+		return
+	}
+
+	callbacks = c.trackCallbacks(callbacks)
+	if c.fifo != nil {
+		c.fifo.push(callbacks...)
+		return
+	}
+
+	for _, fn := range callbacks {
+		go fn()
+	}
+}
+
+// trackCallbacks counts callbacks as pending, returning wrapped callbacks that
+// each mark themselves as done once they return.
+func (c *FakeClock) trackCallbacks(callbacks []func()) []func() {
+	if len(callbacks) == 0 {
+		return callbacks
+	}
+
+	c.mu.Lock()
+	c.pending += len(callbacks)
+	c.mu.Unlock()
+
+	for i, fn := range callbacks {
+		fn := fn
+		callbacks[i] = func() {
+			defer c.callbackDone()
+			fn()
+		}
+	}
+	return callbacks
+}
+
+func (c *FakeClock) callbackDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending--
+	c.cond.Broadcast()
+}
+
+// fireTimers ticks all timers that are due as of now, and returns the
+// callbacks of any due timers that have functions, in the order that they are
+// due. The callbacks are not invoked.
+func (c *FakeClock) fireTimers(now int64) (callbacks []func()) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	num := len(c.timers)
+	var (
+		num         = len(c.timers)
+		rescheduled bool
+	)
+	defer func() {
+		// Tickers that fired were rescheduled in place, so restore the order.
+		if rescheduled {
+			c.sortTimersNosync()
+		}
+	}()
+
 	for i := 0; i < num; /* noincr */ {
-		if when := c.timers[i].when; when < 0 || when > now {
-			return
+		if c.timers[i].when > now {
+			break
 		}
 
 		// This timer should tick. If it has a function, the function should be
-		// called in its own goroutine; otherwise, the channel should receive a
-		// tick.
+		// called; otherwise, the channel should receive a tick.
 		if c.timers[i].fn != nil {
-			go c.timers[i].fn()
+			callbacks = append(callbacks, c.timers[i].fn)
 		} else {
-			tick(c.timers[i].ch, now)
+			c.timers[i].tickNosync(now)
 		}
 
-		// If this is a ticker, extend when by period.
+		// If this is a ticker, extend when by period, unless it was already
+		// rescheduled onto its period when ticked.
 		if c.timers[i].period != 0 {
-			c.timers[i].when = now + c.timers[i].period
+			if c.timers[i].when <= now {
+				c.timers[i].when = now + c.timers[i].period
+			}
+			rescheduled = true
 			i++
 			continue
 		}
@@ -212,16 +559,29 @@ func (c *FakeClock) checkTimers(now int64) {
 		c.timers = c.timers[:num-1]
 		num--
 	}
+
+	return callbacks
 }
 
 func (c *FakeClock) resetTimer(fake *fakeTimer, d time.Duration) bool {
+	return c.resetTimerFunc(fake, d, fake.fn)
+}
+
+// resetTimerFunc is like resetTimer, but also replaces the timer's function
+// with fn.
+func (c *FakeClock) resetTimerFunc(
+	fake *fakeTimer,
+	d time.Duration,
+	fn func(),
+) bool {
 	now := fake.clk.Nanotime()
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Check if the timer exists using its previous value.
-	pos := c.insertPosNosync(fake.when)
+	pos := c.indexNosync(fake)
+
+	fake.fn = fn
 
 	fake.when = now + int64(d)
 	if fake.period != 0 {
@@ -230,73 +590,74 @@ func (c *FakeClock) resetTimer(fake *fakeTimer, d time.Duration) bool {
 
 	// The timer doesn't exist; insert it into its new position based on the
 	// current time and given duration.
-	if n := len(c.timers); n == 0 || pos >= n || c.timers[pos] != fake {
+	if pos < 0 {
 		c.timers = append(c.timers, fake)
-		c.sortTimersNosync()
-		return false
+		c.cond.Broadcast()
 	}
 
-	return true
+	c.sortTimersNosync()
+	return pos >= 0
 }
 
 func (c *FakeClock) removeTimer(fake *fakeTimer) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if len(c.timers) == 0 {
-		return false
-	}
+	return c.removeTimerNosync(fake)
+}
 
-	// Find a candidate timer's index based on the original expiration.
-	pos := c.insertPosNosync(fake.when)
+// stopAndDrainTimer removes fake from the clock and discards any tick in its
+// channel. Because fake timers only tick while the clock's lock is held, no
+// tick can be delivered between the two.
+func (c *FakeClock) stopAndDrainTimer(fake *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// Pathological case: insertPosNosync will always return {0,2} for [2]
-	if pos > 0 && len(c.timers) == 2 {
-		pos--
-	}
+	removed := c.removeTimerNosync(fake)
+	drain(fake.ch)
+	return removed
+}
 
-	// Ensure that this is the expected timer.
-	if pos >= len(c.timers) || c.timers[pos] != fake {
+func (c *FakeClock) removeTimerNosync(fake *fakeTimer) bool {
+	pos := c.indexNosync(fake)
+	if pos < 0 {
 		return false
 	}
 
 	if pos < len(c.timers)-1 {
 		copy(c.timers[pos:], c.timers[pos+1:])
 	}
+	c.timers[len(c.timers)-1] = nil
 	c.timers = c.timers[:len(c.timers)-1]
 
 	return true
 }
 
-func (c *FakeClock) insertPosNosync(when int64) int {
-	// Inline the stdlib search for parity. Ref:
-	// https://cs.opensource.google/go/go/+/refs/tags/go1.18.1:src/sort/search.go;l=59-74
-	i, j := 0, len(c.timers)
-	for i < j {
-		h := int(uint(i+j) >> 1)
-		if cur := c.timers[i].when; cur >= 0 && cur < when {
-			i = h + 1
-		} else {
-			j = h
+// indexNosync returns the index of fake within the clock's timers, or -1 if
+// fake is not currently scheduled.
+func (c *FakeClock) indexNosync(fake *fakeTimer) int {
+	for i := range c.timers {
+		if c.timers[i] == fake {
+			return i
 		}
 	}
-
-	return i
+	return -1
 }
 
 func (c *FakeClock) sortTimersNosync() {
-	sort.Slice(c.timers, func(i int, j int) bool {
-		a, b := c.timers[i], c.timers[j]
-		return b.when < 0 || (a.when >= 0 && a.when < b.when)
+	// Timers that are due at the same time keep their relative order.
+	sort.SliceStable(c.timers, func(i int, j int) bool {
+		return c.timers[i].when < c.timers[j].when
 	})
 }
 
 type fakeTimer struct {
 	clk    *FakeClock
 	ch     chan time.Time
-	fn     func() // timer only
-	when   int64  // timer expiration or next tick
-	period int64  // ticker only
+	fn     func()
+	when   int64 // timer expiration or next tick
+	period int64 // ticker only
+	missed atomic.Int64
 }
 
 func newFakeTimer(clk *FakeClock, d time.Duration, fn func()) *fakeTimer {
@@ -308,26 +669,163 @@ func newFakeTimer(clk *FakeClock, d time.Duration, fn func()) *fakeTimer {
 	}
 }
 
-func newFakeTicker(clk *FakeClock, d time.Duration) *fakeTimer {
+func newFakeTicker(
+	clk *FakeClock,
+	first time.Duration,
+	d time.Duration,
+	fn func(),
+) *fakeTimer {
 	return &fakeTimer{
 		clk:    clk,
-		ch:     make(chan time.Time, 1),
-		when:   clk.Nanotime() + int64(d),
+		ch:     make(chan time.Time, max(clk.options.TickerBuffer, 1)),
+		fn:     fn,
+		when:   clk.Nanotime() + int64(first),
 		period: int64(d),
 	}
 }
 
+// tickNosync delivers the ticks that are due as of now to f's channel. Tickers
+// with buffered channels (see [WithTickerBuffer]) receive a tick for each
+// period that has elapsed, up to the channel's free capacity, and are
+// rescheduled onto their next period; otherwise, a single tick is delivered.
+// Ticks for elapsed periods that are not delivered are counted as missed.
+func (f *fakeTimer) tickNosync(now int64) {
+	if f.period == 0 {
+		tick(f.ch, now)
+		return
+	}
+
+	var (
+		ticks = (now-f.when)/f.period + 1
+		sent  int64
+	)
+	defer func() {
+		f.missed.Add(ticks - sent)
+	}()
+
+	if cap(f.ch) <= 1 {
+		if tick(f.ch, now) {
+			sent++
+		}
+		return
+	}
+
+	for ; sent < ticks && len(f.ch) < cap(f.ch); sent++ {
+		tick(f.ch, f.when+sent*f.period)
+	}
+	f.when += ticks * f.period
+}
+
 func (f *fakeTimer) resetTimer(d time.Duration) bool {
 	return f.clk.resetTimer(f, d)
 }
 
+func (f *fakeTimer) active() bool {
+	f.clk.mu.Lock()
+	defer f.clk.mu.Unlock()
+	return f.clk.indexNosync(f) >= 0
+}
+
+func (f *fakeTimer) resetTimerFunc(d time.Duration, fn func()) bool {
+	return f.clk.resetTimerFunc(f, d, fn)
+}
+
 func (f *fakeTimer) removeTimer() bool {
 	return f.clk.removeTimer(f)
 }
 
-func tick(ch chan time.Time, ns int64) {
+func (f *fakeTimer) stopAndDrain() bool {
+	return f.clk.stopAndDrainTimer(f)
+}
+
+// A fakeTime is the time shared by a [FakeClock] and its forks.
+type fakeTime struct {
+	now    atomic.Int64
+	clocks []*FakeClock
+	mu     sync.Mutex
+}
+
+func (t *fakeTime) join(c *FakeClock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clocks = append(t.clocks, c)
+}
+
+// leave detaches c from t. The clocks are copied rather than modified in
+// place, since checkTimers iterates over them without holding t's lock.
+func (t *fakeTime) leave(c *FakeClock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clocks := make([]*FakeClock, 0, len(t.clocks))
+	for _, x := range t.clocks {
+		if x != c {
+			clocks = append(clocks, x)
+		}
+	}
+	t.clocks = clocks
+}
+
+// checkTimers fires the due timers of every clock sharing t, in the order in
+// which the clocks were created.
+func (t *fakeTime) checkTimers(now int64) {
+	t.mu.Lock()
+	clocks := t.clocks
+	t.mu.Unlock()
+
+	for _, c := range clocks {
+		c.checkTimers(now)
+	}
+}
+
+// A callbackQueue runs callbacks one at a time, in the order that they were
+// pushed, on a goroutine that only runs while the queue is non-empty.
+type callbackQueue struct {
+	fns     []func()
+	running bool
+	mu      sync.Mutex
+}
+
+func (q *callbackQueue) push(fns ...func()) {
+	if len(fns) == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.fns = append(q.fns, fns...)
+	if !q.running {
+		q.running = true
+		go q.drain()
+	}
+}
+
+func (q *callbackQueue) drain() {
+	for {
+		q.mu.Lock()
+		if len(q.fns) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+
+		fn := q.fns[0]
+		q.fns[0] = nil
+		q.fns = q.fns[1:]
+		q.mu.Unlock()
+
+		fn()
+	}
+}
+
+// tick sends ns on ch without blocking, reporting whether it was sent.
+func tick(ch chan time.Time, ns int64) bool {
 	select {
 	case ch <- time.Unix(0, ns):
+		return true
 	default:
+		return false
 	}
 }
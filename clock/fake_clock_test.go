@@ -54,6 +54,48 @@ func TestFakeClock_Add(t *testing.T) {
 	requireClockIs(t, 0, clk)
 }
 
+func TestFakeClock_Advance(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	clk.Advance(time.Second)
+	require.Equal(t, int64(time.Second), clk.Nanotime())
+
+	clk.AdvanceBy()
+	require.Equal(t, int64(time.Second), clk.Nanotime())
+
+	clk.AdvanceBy(time.Second, 2*time.Second, 3*time.Second)
+	require.Equal(t, int64(7*time.Second), clk.Nanotime())
+}
+
+func TestFakeClock_AdvanceBy_Ticker(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock(clock.WithSynchronousCallbacks())
+		ticks []int64
+	)
+
+	ticker := clk.NewTickerFunc(time.Second, func() {
+		ticks = append(ticks, clk.Nanotime())
+	})
+	defer ticker.Stop()
+
+	// A single large step only fires the ticker once.
+	clk.Add(3 * time.Second)
+	require.Equal(t, []int64{int64(3 * time.Second)}, ticks)
+
+	// Stepping fires the ticker at each intermediate point.
+	ticks = ticks[:0]
+	clk.AdvanceBy(time.Second, time.Second, time.Second)
+	require.Equal(
+		t,
+		[]int64{
+			int64(4 * time.Second),
+			int64(5 * time.Second),
+			int64(6 * time.Second),
+		},
+		ticks,
+	)
+}
+
 func TestFakeClock_SetTime(t *testing.T) {
 	clk := clock.NewFakeClock()
 
@@ -124,6 +166,268 @@ func TestFakeClock_AfterFunc(t *testing.T) {
 	}
 }
 
+func TestFakeClock_Timer_ResetFunc(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock(clock.WithSynchronousCallbacks())
+		called []string
+		timer  = clk.AfterFunc(time.Second, func() {
+			called = append(called, "first")
+		})
+	)
+
+	// Replacing the func of a pending timer.
+	require.True(t, timer.ResetFunc(2*time.Second, func() {
+		called = append(called, "second")
+	}))
+	clk.Add(time.Second)
+	require.Empty(t, called)
+	clk.Add(time.Second)
+	require.Equal(t, []string{"second"}, called)
+
+	// Replacing the func of a timer that already fired reschedules it.
+	require.False(t, timer.ResetFunc(time.Second, func() {
+		called = append(called, "third")
+	}))
+	clk.Add(time.Second)
+	require.Equal(t, []string{"second", "third"}, called)
+
+	// Channel timers call the func instead of ticking.
+	ch := clk.NewTimer(time.Second)
+	ch.ResetFunc(time.Second, func() {
+		called = append(called, "fourth")
+	})
+	clk.Add(time.Second)
+	requireNoTick(t, ch.C)
+	require.Equal(t, []string{"second", "third", "fourth"}, called)
+}
+
+func TestFakeClock_SynchronousCallbacks(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock(clock.WithSynchronousCallbacks())
+		order []int
+		add   = func(i int) func() {
+			return func() { order = append(order, i) }
+		}
+	)
+
+	clk.AfterFunc(3*time.Second, add(3))
+	clk.AfterFunc(time.Second, add(1))
+	clk.AfterFunc(2*time.Second, add(2))
+
+	// Callbacks run on this goroutine, in the order they are due, before Add
+	// returns.
+	clk.Add(3 * time.Second)
+	require.Equal(t, []int{1, 2, 3}, order)
+
+	// Callbacks may re-enter the clock.
+	order = nil
+	clk.AfterFunc(time.Second, func() {
+		order = append(order, 1)
+		clk.AfterFunc(time.Second, add(2))
+	})
+
+	clk.Add(time.Second)
+	require.Equal(t, []int{1}, order)
+	clk.Add(time.Second)
+	require.Equal(t, []int{1, 2}, order)
+
+	// Ticker callbacks are also synchronous.
+	order = nil
+	ticker := clk.NewTickerFunc(time.Second, add(0))
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		clk.Add(time.Second)
+		require.Len(t, order, i)
+	}
+}
+
+func TestFakeClock_FIFOCallbacks(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock(clock.WithFIFOCallbacks())
+		order = make(chan int, 32)
+	)
+
+	// Callbacks due at the same time run in the order they were scheduled.
+	for i := 0; i < 10; i++ {
+		i := i
+		clk.AfterFunc(time.Second, func() {
+			order <- i
+		})
+	}
+
+	// Callbacks due later run after, even across separate advances.
+	for i := 10; i < 20; i++ {
+		i := i
+		clk.AfterFunc(time.Duration(i)*time.Second, func() {
+			order <- i
+		})
+	}
+
+	clk.Add(time.Second)
+	for i := 10; i < 20; i++ {
+		clk.SetNanotime(int64(i) * int64(time.Second))
+	}
+
+	for i := 0; i < 20; i++ {
+		select {
+		case have := <-order:
+			require.Equal(t, i, have)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for callback")
+		}
+	}
+}
+
+func TestFakeClock_FIFOCallbacks_Blocking(t *testing.T) {
+	var (
+		clk  = clock.NewFakeClock(clock.WithFIFOCallbacks())
+		done = make(chan struct{})
+	)
+
+	// Unlike synchronous callbacks, FIFO callbacks may wait for the clock.
+	clk.AfterFunc(time.Second, func() {
+		clk.Sleep(time.Second)
+		close(done)
+	})
+
+	clk.Add(time.Second)
+	clk.BlockUntil(1)
+	clk.Add(time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for callback")
+	}
+}
+
+func TestFakeClock_Drift(t *testing.T) {
+	cases := map[string]struct {
+		ratio      float64
+		wantPerSec time.Duration
+	}{
+		"fast": {
+			ratio:      0.1,
+			wantPerSec: 1100 * time.Millisecond,
+		},
+		"slow": {
+			ratio:      -0.1,
+			wantPerSec: 900 * time.Millisecond,
+		},
+		"none": {
+			ratio:      0,
+			wantPerSec: time.Second,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk   = clock.NewFakeClock(clock.WithFakeDrift(tt.ratio))
+				timer = clk.NewTimer(time.Second)
+			)
+
+			clk.Add(time.Second)
+			requireClockIs(t, int64(tt.wantPerSec), clk)
+
+			// Timers are scheduled against the drifted time.
+			if tt.wantPerSec < time.Second {
+				requireNoTick(t, timer.C)
+				clk.Add(time.Second)
+				requireTick(t, timer.C)
+			} else {
+				requireTick(t, timer.C)
+			}
+
+			// Setting the time bypasses drift.
+			clk.SetNanotime(int64(time.Minute))
+			requireClockIs(t, int64(time.Minute), clk)
+		})
+	}
+}
+
+func TestFakeOptions(t *testing.T) {
+	require.Equal(t, clock.DefaultFakeOptions(), clock.DefaultFakeOptions().With())
+	require.Equal(
+		t,
+		clock.FakeOptions{SynchronousCallbacks: true},
+		clock.DefaultFakeOptions().With(clock.WithSynchronousCallbacks()),
+	)
+	require.Equal(
+		t,
+		clock.FakeOptions{SynchronousCallbacks: true, Drift: 0.5},
+		clock.DefaultFakeOptions().With(
+			clock.FakeOptions{SynchronousCallbacks: true},
+			clock.WithFakeDrift(0.5),
+			clock.FakeOptions{},
+		),
+	)
+	require.Equal(
+		t,
+		clock.FakeOptions{TickerBuffer: 2, Location: time.UTC},
+		clock.DefaultFakeOptions().With(
+			clock.WithTickerBuffer(2),
+			clock.WithTickerBuffer(0),
+			clock.WithFakeLocation(time.UTC),
+			clock.WithFakeLocation(nil),
+		),
+	)
+}
+
+func TestFakeClock_WithFakeLocation(t *testing.T) {
+	loc := time.FixedZone("test", -7*60*60)
+
+	clk := clock.NewFakeClock(clock.WithFakeLocation(loc))
+	clk.SetTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.Equal(t, loc, clk.Now().Location())
+	require.Equal(t, "2023-01-01T20:04:05-07:00", clk.Now().Format(time.RFC3339))
+
+	// Without the option, time is reported in the local time zone.
+	require.Equal(t, time.Local, clock.NewFakeClock().Now().Location())
+}
+
+func TestFakeClock_Reset(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	var (
+		timer  = clk.NewTimer(time.Second)
+		ticker = clk.NewTicker(time.Second)
+		called = make(chan struct{}, 1)
+	)
+	defer ticker.Stop()
+	clk.AfterFunc(time.Second, func() {
+		called <- struct{}{}
+	})
+
+	clk.Add(time.Hour)
+	requireTick(t, timer.C)
+	requireTick(t, ticker.C)
+	<-called
+
+	timer.Reset(time.Second)
+	clk.Reset()
+	require.Equal(t, int64(0), clk.Nanotime())
+
+	// Nothing that was scheduled before the reset should fire.
+	clk.Add(time.Hour)
+	requireNoTick(t, timer.C)
+	requireNoTick(t, ticker.C)
+	require.False(t, timer.Stop())
+	require.False(t, ticker.ResetActive(time.Second))
+
+	// Discarded tickers can be rescheduled, and the clock otherwise behaves
+	// as if it were new.
+	clk.Reset()
+	ticker.Reset(time.Second)
+	after := clk.After(2 * time.Second)
+	clk.Add(time.Second)
+	require.Equal(t, time.Unix(0, int64(time.Second)), requireTick(t, ticker.C))
+	requireNoTick(t, after)
+	clk.Add(time.Second)
+	requireTick(t, after)
+}
+
 func TestFakeClockSince(t *testing.T) {
 	var (
 		clk   = clock.NewFakeClock()
@@ -165,6 +469,26 @@ func TestFakeClock_NewTimer(t *testing.T) {
 	requireNoTick(t, timer.C)
 }
 
+func TestFakeClock_Timer_Active(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		timer = clk.NewTimer(time.Second)
+	)
+
+	require.True(t, timer.Active())
+	clk.Add(time.Second)
+	require.False(t, timer.Active())
+
+	timer.Reset(time.Second)
+	require.True(t, timer.Active())
+	timer.Stop()
+	require.False(t, timer.Active())
+
+	timer.Reset(time.Second)
+	clk.Reset()
+	require.False(t, timer.Active())
+}
+
 func TestFakeClock_Timer_Zeroes(t *testing.T) {
 	var (
 		clk   = clock.NewFakeClock()
@@ -197,6 +521,22 @@ func TestFakeClock_Timer_Zeroes(t *testing.T) {
 	require.True(t, timer.Stop())
 }
 
+func TestFakeClock_Chan(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		timer  = clk.NewTimer(time.Second)
+		ticker = clk.NewTicker(time.Second)
+	)
+	defer ticker.Stop()
+
+	require.Equal(t, timer.C, timer.Chan())
+	require.Equal(t, ticker.C, ticker.Chan())
+
+	clk.Add(time.Second)
+	requireTick(t, timer.Chan())
+	requireTick(t, ticker.Chan())
+}
+
 func TestFakeClock_NewTicker(t *testing.T) {
 	var (
 		clk    = clock.NewFakeClock()
@@ -225,6 +565,72 @@ func TestFakeClock_NewTicker(t *testing.T) {
 	})
 }
 
+func TestFakeClock_NewAlignedTicker(t *testing.T) {
+	clk := clock.NewFakeClock()
+	clk.SetNanotime(int64(90 * time.Second))
+
+	ticker := clk.NewAlignedTicker(time.Minute)
+	defer ticker.Stop()
+
+	// The first tick arrives at the next minute boundary.
+	clk.Add(29 * time.Second)
+	requireNoTick(t, ticker.C)
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(2*time.Minute), requireTick(t, ticker.C))
+
+	// Subsequent ticks arrive every minute thereafter.
+	clk.Add(59 * time.Second)
+	requireNoTick(t, ticker.C)
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(3*time.Minute), requireTick(t, ticker.C))
+
+	// A clock that is already on a boundary ticks at the next one.
+	clk.SetNanotime(0)
+	onBoundary := clk.NewAlignedTicker(time.Minute)
+	defer onBoundary.Stop()
+	clk.Add(time.Minute - 1)
+	requireNoTick(t, onBoundary.C)
+	clk.Add(1)
+	requireTick(t, onBoundary.C)
+
+	// Negative times are aligned to the same boundaries.
+	clk.SetNanotime(int64(-30 * time.Second))
+	negative := clk.NewAlignedTicker(time.Minute)
+	defer negative.Stop()
+	clk.Add(30 * time.Second)
+	requireTimeIs(t, 0, requireTick(t, negative.C))
+
+	require.Panics(t, func() {
+		clk.NewAlignedTicker(0)
+	})
+}
+
+func TestFakeClock_NewTickerFunc(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		calls  = atomic.NewInt64(0)
+		ticker = clk.NewTickerFunc(time.Second, func() { calls.Inc() })
+	)
+
+	for i := int64(0); i < 10; i++ {
+		requireNoTick(t, ticker.C)
+		clk.Add(time.Second)
+		waitFor(t, time.Second, func() bool {
+			return calls.Load() == i+1
+		})
+	}
+
+	// Stopping the ticker stops further calls.
+	ticker.Stop()
+	clk.Add(time.Second)
+	time.Sleep(10 * time.Millisecond)
+	require.EqualValues(t, 10, calls.Load())
+
+	require.Panics(t, func() {
+		clk.NewTickerFunc(0, func() {})
+	})
+}
+
 //nolint:gocyclo
 func TestFakeClock_Ticker_Goroutine(t *testing.T) {
 	var (
@@ -283,6 +689,74 @@ func TestFakeClock_Ticker_Goroutine(t *testing.T) {
 	}
 }
 
+func TestFakeClock_BlockUntil(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		ticks = make(chan struct{}, 10)
+		wg    sync.WaitGroup
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	for i := 0; i < cap(ticks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ticker := clk.NewTicker(time.Second)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case ticks <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	clk.BlockUntil(cap(ticks))
+	clk.Add(time.Second)
+
+	for i := 0; i < cap(ticks); i++ {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for ticker ticks")
+		}
+	}
+
+	// Sleeping counts as a waiter.
+	sleepdone := make(chan struct{})
+	go func() {
+		defer close(sleepdone)
+		clk.Sleep(time.Second)
+	}()
+
+	clk.BlockUntil(cap(ticks) + 1)
+	clk.Add(time.Second)
+
+	select {
+	case <-sleepdone:
+	case <-time.After(time.Second):
+		require.FailNow(t, "sleep did not wake")
+	}
+
+	// Already satisfied; should not block.
+	clk.BlockUntil(0)
+}
+
 func TestFakeClock_Ticker_Zeroes(t *testing.T) {
 	var (
 		clk    = clock.NewFakeClock()
@@ -303,6 +777,33 @@ func TestFakeClock_Ticker_Zeroes(t *testing.T) {
 	})
 }
 
+func TestFakeClock_Ticker_ResetActive(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		ticker = clk.NewTicker(time.Second)
+	)
+
+	require.True(t, ticker.ResetActive(time.Second))
+	clk.Add(time.Second)
+	requireTick(t, ticker.C)
+
+	// Tickers remain active after ticking.
+	require.True(t, ticker.ResetActive(time.Second))
+
+	ticker.Stop()
+	require.False(t, ticker.ResetActive(time.Second))
+	require.True(t, ticker.ResetActive(2*time.Second))
+
+	clk.Add(time.Second)
+	requireNoTick(t, ticker.C)
+	clk.Add(time.Second)
+	requireTick(t, ticker.C)
+
+	require.Panics(t, func() {
+		ticker.ResetActive(0)
+	})
+}
+
 func TestFakeClock_Tick(t *testing.T) {
 	var (
 		clk     = clock.NewFakeClock()
@@ -324,28 +825,258 @@ func TestFakeClock_Tick(t *testing.T) {
 	})
 }
 
-func TestFakeClock_Sleep(t *testing.T) {
+func TestFakeClock_TickContext(t *testing.T) {
 	var (
-		clk       = clock.NewFakeClock()
-		sleepdone = make(chan struct{})
+		clk         = clock.NewFakeClock()
+		ctx, cancel = context.WithCancel(context.Background())
+		tickerC     = clk.TickContext(ctx, time.Second)
 	)
-	go func() {
-		defer close(sleepdone)
-		clk.Sleep(time.Second)
-	}()
 
 	for i := 0; i < 10; i++ {
+		requireNoTick(t, tickerC)
 		clk.Add(time.Second)
-		time.Sleep(time.Millisecond)
+		requireTick(t, tickerC)
 	}
 
-	select {
-	case <-sleepdone:
-	case <-time.After(time.Second):
-		require.Fail(t, "sleep did not wake")
+	cancel()
+	requireClosed(t, tickerC)
+
+	// Advancing the clock after cancellation should not panic or tick.
+	clk.Add(time.Minute)
+
+	require.Panics(t, func() {
+		clk.TickContext(context.Background(), 0)
+	})
+}
+
+func TestFakeClock_DeadlineContext(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	ctx, cancel := clk.DeadlineContext(
+		context.Background(),
+		int64(5*time.Second),
+	)
+	defer cancel()
+
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	// The fake deadline is not reported as a real one.
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+	_, ok = child.Deadline()
+	require.False(t, ok)
+
+	deadline, ok := clock.FakeDeadline(ctx)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(0, int64(5*time.Second)), deadline)
+	deadline, ok = clock.FakeDeadline(child)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(0, int64(5*time.Second)), deadline)
+
+	// Real time has no effect on the context.
+	clk.Add(4 * time.Second)
+	requireNotDone(t, ctx)
+	require.NoError(t, ctx.Err())
+
+	clk.Add(time.Second)
+	requireContextDone(t, ctx)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	requireContextDone(t, child)
+	require.ErrorIs(t, child.Err(), context.DeadlineExceeded)
+
+	// Canceling after the deadline does not change the error.
+	cancel()
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestFakeClock_TimeoutContext(t *testing.T) {
+	clk := clock.NewFakeClock()
+	clk.Add(time.Hour)
+
+	ctx, cancel := clk.TimeoutContext(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+
+	deadline, ok := clock.FakeDeadline(ctx)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(0, int64(time.Hour+5*time.Second)), deadline)
+
+	clk.Add(4 * time.Second)
+	requireNotDone(t, ctx)
+
+	clk.Add(time.Second)
+	requireContextDone(t, ctx)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestFakeClock_DeadlineContext_Canceled(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	// Canceled directly.
+	ctx, cancel := clk.DeadlineContext(context.Background(), int64(time.Second))
+	cancel()
+	requireContextDone(t, ctx)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// Canceled via its parent.
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = clk.DeadlineContext(parent, int64(time.Second))
+	defer cancel()
+	cancelParent()
+	requireContextDone(t, ctx)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// Reaching the deadline after cancellation has no effect.
+	clk.Add(time.Second)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// A deadline that has already passed is immediately exceeded.
+	ctx, cancel = clk.DeadlineContext(context.Background(), 0)
+	defer cancel()
+	requireContextDone(t, ctx)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+	// An earlier fake parent deadline takes precedence.
+	parent, cancelParent = clk.DeadlineContext(
+		context.Background(),
+		int64(time.Hour),
+	)
+	defer cancelParent()
+	ctx, cancel = clk.DeadlineContext(parent, int64(2*time.Hour))
+	defer cancel()
+	deadline, ok := clock.FakeDeadline(ctx)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(0, int64(time.Hour)), deadline)
+
+	// Real parent deadlines are reported as-is.
+	want := time.Now().Add(time.Hour)
+	parent, cancelParent = context.WithDeadline(context.Background(), want)
+	defer cancelParent()
+	ctx, cancel = clk.DeadlineContext(parent, int64(2*time.Hour))
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, want, deadline)
+}
+
+func TestFakeClock_DeadlineContext_Derived(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	ctx, cancel := clk.TimeoutContext(context.Background(), time.Hour)
+	defer cancel()
+
+	// Standard library contexts derived from a fake deadline keep their own
+	// real deadlines.
+	child, cancelChild := context.WithTimeout(ctx, time.Hour)
+	defer cancelChild()
+
+	deadline, ok := child.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Minute)
+	requireNotDone(t, child)
+
+	clk.Add(time.Hour)
+	requireContextDone(t, child)
+	require.ErrorIs(t, child.Err(), context.DeadlineExceeded)
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		sleepdone = make(chan struct{})
+	)
+	go func() {
+		defer close(sleepdone)
+		clk.Sleep(time.Second)
+	}()
+
+	for i := 0; i < 10; i++ {
+		clk.Add(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case <-sleepdone:
+	case <-time.After(time.Second):
+		require.Fail(t, "sleep did not wake")
 	}
 }
 
+func TestFakeClock_SleepUntil(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		sleepdone = make(chan struct{})
+	)
+	go func() {
+		defer close(sleepdone)
+		clk.SleepUntil(time.Unix(0, int64(3*time.Second)))
+		clk.SleepUntilNanotime(int64(5 * time.Second))
+	}()
+
+	// The sleeper only wakes once the clock reaches each target exactly.
+	clk.BlockUntil(1)
+	clk.Add(2 * time.Second)
+	select {
+	case <-sleepdone:
+		require.FailNow(t, "sleep woke early")
+	case <-time.After(10 * time.Millisecond):
+	}
+	clk.Add(time.Second)
+
+	clk.BlockUntil(1)
+	clk.Add(time.Second)
+	select {
+	case <-sleepdone:
+		require.FailNow(t, "sleep woke early")
+	case <-time.After(10 * time.Millisecond):
+	}
+	clk.Add(time.Second)
+
+	select {
+	case <-sleepdone:
+	case <-time.After(time.Second):
+		require.Fail(t, "sleep did not wake")
+	}
+
+	// Targets in the past return immediately.
+	clk.SleepUntil(time.Unix(0, 0))
+	clk.SleepUntilNanotime(clk.Nanotime())
+}
+
+func TestFakeClock_Close(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		timer     = clk.NewTimer(time.Second)
+		sleepdone = make(chan struct{})
+	)
+	go func() {
+		defer close(sleepdone)
+		clk.Sleep(time.Hour)
+		clk.SleepUntilNanotime(int64(time.Hour))
+	}()
+
+	// Sleepers are woken, and pending timers are discarded without firing.
+	clk.BlockUntil(2)
+	clk.Close()
+	clk.Close()
+
+	select {
+	case <-sleepdone:
+	case <-time.After(time.Second):
+		require.FailNow(t, "sleep did not wake")
+	}
+
+	require.Zero(t, clk.Nanotime())
+	clk.Add(time.Second)
+	requireNoTick(t, timer.C)
+
+	// Sleeping on a closed clock returns immediately.
+	clk.Sleep(time.Hour)
+}
+
 func TestFakeClock_InterleavedTimers(t *testing.T) {
 	var (
 		clk    = clock.NewFakeClock()
@@ -432,6 +1163,76 @@ func TestFakeClock_ManyTimers(t *testing.T) {
 	}
 }
 
+func TestFakeClock_Timer_BackwardThenForward(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		timer = clk.NewTimer(5 * time.Second)
+	)
+
+	// Crossing the deadline fires the timer once.
+	clk.SetNanotime(int64(10 * time.Second))
+	requireTimeIs(t, int64(10*time.Second), requireTick(t, timer.C))
+
+	// Moving backward across the deadline and then forward again does not
+	// fire the timer again.
+	clk.SetNanotime(0)
+	requireNoTick(t, timer.C)
+	clk.SetNanotime(int64(10 * time.Second))
+	requireNoTick(t, timer.C)
+	require.False(t, timer.Active())
+
+	// A timer that has not fired yet is unaffected by moving backward, and
+	// fires when its deadline is crossed.
+	timer.Reset(5 * time.Second)
+	clk.SetNanotime(int64(5 * time.Second))
+	requireNoTick(t, timer.C)
+	clk.SetNanotime(int64(15*time.Second) - 1)
+	requireNoTick(t, timer.C)
+	clk.SetNanotime(int64(15 * time.Second))
+	requireTimeIs(t, int64(15*time.Second), requireTick(t, timer.C))
+}
+
+func TestFakeClock_Timer_NegativeTime(t *testing.T) {
+	clk := clock.NewFakeClock()
+	clk.SetNanotime(int64(-10 * time.Second))
+
+	var (
+		timer  = clk.NewTimer(5 * time.Second)
+		ticker = clk.NewTicker(5 * time.Second)
+	)
+	defer ticker.Stop()
+
+	// Timers that are due at negative times fire like any other.
+	clk.Add(5 * time.Second)
+	requireTimeIs(t, int64(-5*time.Second), requireTick(t, timer.C))
+	requireTimeIs(t, int64(-5*time.Second), requireTick(t, ticker.C))
+
+	clk.Add(5 * time.Second)
+	requireTimeIs(t, 0, requireTick(t, ticker.C))
+}
+
+func TestFakeClock_Ticker_RescheduleOrder(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		ticker = clk.NewTicker(10 * time.Second)
+		timer  = clk.NewTimer(15 * time.Second)
+	)
+	defer ticker.Stop()
+
+	// The ticker fires and is rescheduled past the timer.
+	clk.Add(10 * time.Second)
+	requireTick(t, ticker.C)
+	requireNoTick(t, timer.C)
+
+	// The timer must still fire at its own deadline.
+	clk.Add(5 * time.Second)
+	requireTimeIs(t, int64(15*time.Second), requireTick(t, timer.C))
+	requireNoTick(t, ticker.C)
+
+	clk.Add(5 * time.Second)
+	requireTimeIs(t, int64(20*time.Second), requireTick(t, ticker.C))
+}
+
 func TestFakeClock_Timer_DoubleStop(t *testing.T) {
 	cases := [][]time.Duration{
 		{time.Second, 5 * time.Second},
@@ -460,6 +1261,294 @@ func TestFakeClock_Timer_DoubleStop(t *testing.T) {
 	}
 }
 
+func TestFakeClock_Timer_StopOutOfOrder(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		timers []*clock.Timer
+	)
+
+	for i := 1; i <= 5; i++ {
+		timers = append(timers, clk.NewTimer(time.Duration(i)*time.Second))
+	}
+
+	// Stop timers from the middle of the schedule.
+	require.True(t, timers[1].Stop())
+	require.False(t, timers[1].Stop())
+	require.True(t, timers[3].Stop())
+	require.False(t, timers[3].Stop())
+
+	clk.Add(5 * time.Second)
+	for i, timer := range timers {
+		if i == 1 || i == 3 {
+			requireNoTick(t, timer.C)
+		} else {
+			requireTick(t, timer.C)
+		}
+	}
+}
+
+func TestFakeClock_Timer_ResetReorders(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		timer1 = clk.NewTimer(time.Second)
+		timer2 = clk.NewTimer(2 * time.Second)
+	)
+
+	// Push the first timer past the second; the second should still fire on
+	// time.
+	require.True(t, timer1.Reset(3*time.Second))
+
+	clk.Add(2 * time.Second)
+	requireNoTick(t, timer1.C)
+	requireTick(t, timer2.C)
+
+	clk.Add(time.Second)
+	requireTick(t, timer1.C)
+}
+
+func TestFakeClock_Fork(t *testing.T) {
+	var (
+		parent = clock.NewFakeClock()
+		child  = parent.Fork()
+		pch    = parent.After(time.Second)
+		cch    = child.After(2 * time.Second)
+	)
+
+	// Advancing either clock advances both, and fires both clocks' timers.
+	parent.Add(time.Second)
+	requireClockIs(t, int64(time.Second), child)
+	requireTick(t, pch)
+	requireNoTick(t, cch)
+
+	child.Add(time.Second)
+	requireClockIs(t, int64(2*time.Second), parent)
+	requireTick(t, cch)
+
+	// Each clock has its own timers.
+	parent.After(time.Second)
+	child.After(time.Second)
+	child.After(time.Second)
+	parent.BlockUntil(1)
+	child.BlockUntil(2)
+
+	// Resetting the child resets the shared time but only the child's timers.
+	child.Reset()
+	requireClockIs(t, 0, parent)
+	child.BlockUntil(0)
+	parent.BlockUntil(1)
+}
+
+func TestFakeClock_Fork_Close(t *testing.T) {
+	var (
+		parent = clock.NewFakeClock()
+		child  = parent.Fork()
+		pch    = parent.After(time.Second)
+	)
+
+	// A closed fork still reports the shared time, but its timers no longer
+	// fire, while the other clocks are unaffected.
+	child.Close()
+	cch := child.After(time.Second)
+
+	parent.Add(time.Second)
+	requireClockIs(t, int64(time.Second), child)
+	requireTick(t, pch)
+	requireNoTick(t, cch)
+
+	child.Add(time.Second)
+	requireClockIs(t, int64(2*time.Second), parent)
+	requireNoTick(t, cch)
+}
+
+func TestFakeClock_Drain(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		timer  = clk.NewTimer(time.Second)
+		ticker = clk.NewTicker(time.Second)
+	)
+	defer timer.Stop()
+	defer ticker.Stop()
+
+	require.Zero(t, timer.Drain())
+	require.Zero(t, ticker.Drain())
+
+	// Only the most recent tick is buffered, regardless of how many periods
+	// have elapsed.
+	clk.Add(3 * time.Second)
+	require.Equal(t, 1, timer.Drain())
+	require.Equal(t, 1, ticker.Drain())
+	require.Zero(t, timer.Drain())
+	require.Zero(t, ticker.Drain())
+
+	clk.Add(time.Second)
+	requireNoTick(t, timer.C)
+	requireTick(t, ticker.C)
+
+	// Timers without channels have nothing to drain.
+	require.Zero(t, clk.AfterFunc(time.Second, func() {}).Drain())
+}
+
+func TestFakeClock_FlushCallbacks(t *testing.T) {
+	cases := map[string][]clock.FakeOption{
+		"async": nil,
+		"fifo":  {clock.WithFIFOCallbacks()},
+		"sync":  {clock.WithSynchronousCallbacks()},
+	}
+
+	for name, opts := range cases {
+		opts := opts
+		t.Run(name, func(t *testing.T) {
+			var (
+				clk    = clock.NewFakeClock(opts...)
+				calls  atomic.Int64
+				nested = make(chan struct{})
+			)
+
+			// Nothing has been started, so there is nothing to wait for.
+			clk.FlushCallbacks()
+
+			for i := 0; i < 10; i++ {
+				clk.AfterFunc(time.Second, func() {
+					time.Sleep(time.Millisecond)
+					calls.Inc()
+				})
+			}
+
+			// A callback that schedules a new timer does not wait for it.
+			clk.AfterFunc(time.Second, func() {
+				clk.AfterFunc(time.Second, func() {
+					close(nested)
+				})
+			})
+
+			clk.Add(time.Second)
+			clk.FlushCallbacks()
+			require.EqualValues(t, 10, calls.Load())
+
+			clk.Add(time.Second)
+			clk.FlushCallbacks()
+			requireRecv(t, nested)
+		})
+	}
+}
+
+func TestFakeClock_TickerBuffer(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock(clock.WithTickerBuffer(3))
+		ticker = clk.NewTicker(time.Second)
+		timer  = clk.NewTimer(time.Second)
+	)
+	defer ticker.Stop()
+	defer timer.Stop()
+
+	// Each elapsed period delivers a tick, up to the channel's capacity.
+	clk.Add(5*time.Second + time.Millisecond)
+	for i := int64(1); i <= 3; i++ {
+		requireTimeIs(t, i*int64(time.Second), requireTick(t, ticker.C))
+	}
+	requireNoTick(t, ticker.C)
+	require.Equal(t, 1, timer.Drain())
+
+	// The ticker remains aligned to its original period.
+	clk.Add(999 * time.Millisecond)
+	requireTimeIs(t, int64(6*time.Second), requireTick(t, ticker.C))
+	clk.Add(time.Second)
+	requireTimeIs(t, int64(7*time.Second), requireTick(t, ticker.C))
+	requireNoTick(t, ticker.C)
+}
+
+func TestFakeClock_Ticker_Missed(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		buffered = clock.NewFakeClock(clock.WithTickerBuffer(3))
+		ticker   = clk.NewTicker(time.Second)
+		bticker  = buffered.NewTicker(time.Second)
+	)
+	defer ticker.Stop()
+	defer bticker.Stop()
+
+	require.Zero(t, ticker.Missed())
+
+	// Periods that elapse while advancing the clock at once are missed.
+	clk.Add(5 * time.Second)
+	require.EqualValues(t, 4, ticker.Missed())
+
+	// Ticks that are dropped because the channel is full are missed.
+	clk.Add(time.Second)
+	require.EqualValues(t, 5, ticker.Missed())
+	requireTick(t, ticker.C)
+	clk.Add(time.Second)
+	require.EqualValues(t, 5, ticker.Missed())
+
+	// Buffered tickers only miss ticks once their buffers are full.
+	buffered.Add(5 * time.Second)
+	require.EqualValues(t, 2, bticker.Missed())
+
+	// Runtime tickers do not track missed ticks.
+	rticker := clock.NewMonotonicClock().NewTicker(time.Millisecond)
+	defer rticker.Stop()
+	time.Sleep(5 * time.Millisecond)
+	require.Zero(t, rticker.Missed())
+}
+
+func TestFakeClock_Timer_StopAndDrain(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		timer = clk.NewTimer(time.Second)
+	)
+
+	// Stopping a timer that already fired may leave its tick behind.
+	clk.Add(time.Second)
+	require.False(t, timer.Stop())
+	require.Equal(t, 1, timer.Drain())
+
+	// StopAndDrain discards it.
+	require.False(t, timer.Reset(time.Second))
+	clk.Add(time.Second)
+	require.False(t, timer.StopAndDrain())
+	requireNoTick(t, timer.C)
+
+	// Stopping an active timer reports that it was active.
+	require.False(t, timer.Reset(time.Second))
+	require.True(t, timer.StopAndDrain())
+	clk.Add(time.Second)
+	requireNoTick(t, timer.C)
+}
+
+func TestFakeClock_AfterFuncContext(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock(clock.WithSynchronousCallbacks())
+		calls  atomic.Int64
+		called = func() {
+			calls.Inc()
+		}
+	)
+
+	// The func is called once d elapses if ctx is not done.
+	clk.AfterFuncContext(context.Background(), time.Second, called)
+	clk.Add(time.Second)
+	require.EqualValues(t, 1, calls.Load())
+
+	// The func is not called if ctx is done before d elapses.
+	ctx, cancel := context.WithCancel(context.Background())
+	clk.AfterFuncContext(ctx, time.Second, called)
+	cancel()
+	clk.Add(time.Second)
+	require.EqualValues(t, 1, calls.Load())
+
+	// Nor is it called if ctx is already done.
+	clk.AfterFuncContext(ctx, 0, called)
+	clk.Add(time.Second)
+	require.EqualValues(t, 1, calls.Load())
+
+	// Canceling ctx after the func was called has no effect.
+	ctx, cancel = context.WithCancel(context.Background())
+	clk.AfterFuncContext(ctx, time.Second, called)
+	clk.Add(time.Second)
+	cancel()
+	require.EqualValues(t, 2, calls.Load())
+}
+
 func TestFakeClock_Stopwatch(t *testing.T) {
 	var (
 		clk       = clock.NewFakeClock()
@@ -480,6 +1569,124 @@ func TestFakeClock_Stopwatch(t *testing.T) {
 	require.Equal(t, time.Second, stopwatch.Elapsed())
 }
 
+func TestFakeClock_Stopwatch_ElapsedSince(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		stopwatch = clk.NewStopwatch()
+	)
+
+	clk.Add(time.Second)
+	var (
+		ns = clk.Nanotime()
+		ts = clk.Now()
+	)
+
+	clk.Add(2 * time.Second)
+	require.Equal(t, 2*time.Second, stopwatch.ElapsedSince(ns))
+	require.Equal(t, 2*time.Second, stopwatch.ElapsedSinceTime(ts))
+
+	// Neither call affects the stopwatch's own epoch.
+	require.Equal(t, 3*time.Second, stopwatch.Elapsed())
+	total, sinceLast := stopwatch.Split()
+	require.Equal(t, 3*time.Second, total)
+	require.Equal(t, 3*time.Second, sinceLast)
+}
+
+func TestFakeClock_Stopwatch_Split(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		stopwatch = clk.NewStopwatch()
+	)
+
+	total, sinceLast := stopwatch.Split()
+	require.Equal(t, time.Duration(0), total)
+	require.Equal(t, time.Duration(0), sinceLast)
+
+	for i := 1; i <= 3; i++ {
+		clk.Add(time.Second)
+		total, sinceLast = stopwatch.Split()
+		require.Equal(t, time.Duration(i)*time.Second, total)
+		require.Equal(t, time.Second, sinceLast)
+	}
+
+	// Splitting does not affect the epoch.
+	require.Equal(t, 3*time.Second, stopwatch.Elapsed())
+
+	// Resetting moves both the epoch and the split marker.
+	clk.Add(time.Second)
+	require.Equal(t, 4*time.Second, stopwatch.Reset())
+	clk.Add(2 * time.Second)
+	total, sinceLast = stopwatch.Split()
+	require.Equal(t, 2*time.Second, total)
+	require.Equal(t, 2*time.Second, sinceLast)
+}
+
+func TestFakeClock_Stopwatch_Stopped(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		stopwatch = clk.NewStopwatch(clock.WithStopped())
+	)
+
+	// A stopped stopwatch reports no elapsed time.
+	require.False(t, stopwatch.Running())
+	clk.Add(time.Second)
+	require.Equal(t, time.Duration(0), stopwatch.Elapsed())
+
+	// Starting uses the current time as the epoch.
+	stopwatch.Start()
+	require.True(t, stopwatch.Running())
+	clk.Add(2 * time.Second)
+	require.Equal(t, 2*time.Second, stopwatch.Elapsed())
+
+	// Stopping freezes the elapsed time, including for Split and Reset.
+	require.Equal(t, 2*time.Second, stopwatch.Stop())
+	clk.Add(time.Second)
+	require.Equal(t, 2*time.Second, stopwatch.Stop())
+	require.Equal(t, 2*time.Second, stopwatch.Elapsed())
+	total, sinceLast := stopwatch.Split()
+	require.Equal(t, 2*time.Second, total)
+	require.Equal(t, 2*time.Second, sinceLast)
+	require.Equal(t, 2*time.Second, stopwatch.Reset())
+	require.Equal(t, time.Duration(0), stopwatch.Elapsed())
+	require.False(t, stopwatch.Running())
+
+	// Restarting discards previously elapsed time.
+	stopwatch.Start()
+	clk.Add(time.Second)
+	require.Equal(t, time.Second, stopwatch.Elapsed())
+	stopwatch.Start()
+	require.Equal(t, time.Duration(0), stopwatch.Elapsed())
+}
+
+func TestFakeClock_Stopwatch_MonotonicGuard(t *testing.T) {
+	var (
+		clk       = clock.NewFakeClock()
+		guarded   = clk.NewStopwatch(clock.WithMonotonicGuard())
+		unguarded = clk.NewStopwatch()
+	)
+
+	clk.Add(-time.Second)
+	require.Equal(t, -time.Second, unguarded.Elapsed())
+	require.Zero(t, guarded.Elapsed())
+	total, sinceLast := guarded.Split()
+	require.Zero(t, total)
+	require.Zero(t, sinceLast)
+	require.Zero(t, guarded.Stop())
+
+	// The epoch is unchanged, so time is reported again once the clock has
+	// caught back up.
+	guarded.Start()
+	clk.Add(-time.Second)
+	require.Zero(t, guarded.Elapsed())
+	clk.Add(3 * time.Second)
+	require.Equal(t, 2*time.Second, guarded.Elapsed())
+	clk.Add(-3 * time.Second)
+	require.Zero(t, guarded.Reset())
+
+	// ElapsedSince is unaffected.
+	require.Equal(t, -time.Second, guarded.ElapsedSince(clk.Nanotime()+int64(time.Second)))
+}
+
 func requireClockSince(t *testing.T, expect int64, since int64, clk *clock.FakeClock) {
 	require.EqualValues(t, expect, clk.Since(time.Unix(0, since)))
 	require.EqualValues(t, expect, clk.SinceNanotime(since))
@@ -515,6 +1722,36 @@ func requireNoTick(t *testing.T, ch <-chan time.Time) {
 	}
 }
 
+func requireContextDone(t *testing.T, ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for context to be done")
+	}
+}
+
+func requireNotDone(t *testing.T, ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		require.FailNow(t, "context is unexpectedly done")
+	default:
+	}
+}
+
+func requireClosed(t *testing.T, ch <-chan time.Time) {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for channel to close")
+		}
+	}
+}
+
 func waitFor(t *testing.T, d time.Duration, f func() bool) {
 	start := chrono.Nanotime()
 	for !f() {
@@ -0,0 +1,177 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import "time"
+
+// FakeOptions configure a [FakeClock].
+type FakeOptions struct {
+	// SynchronousCallbacks configures whether callbacks scheduled via
+	// [FakeClock.AfterFunc] and [FakeClock.NewTickerFunc] are run
+	// synchronously on the goroutine that advances the clock, rather than
+	// each in their own goroutine. See [WithSynchronousCallbacks] for more
+	// information.
+	SynchronousCallbacks bool
+	// FIFOCallbacks configures whether callbacks scheduled via
+	// [FakeClock.AfterFunc] and [FakeClock.NewTickerFunc] are run one at a
+	// time, in the order that they are due, on a goroutine separate from the
+	// one that advances the clock. See [WithFIFOCallbacks] for more
+	// information.
+	FIFOCallbacks bool
+	// Drift configures the ratio by which each call to [FakeClock.Add]
+	// over- or under-advances the clock. See [WithFakeDrift] for more
+	// information.
+	Drift float64
+	// TickerBuffer configures the capacity of the channels of tickers created
+	// by a [FakeClock]. See [WithTickerBuffer] for more information.
+	TickerBuffer int
+	// Location configures the [time.Location] in which [FakeClock.Now]
+	// reports time. See [WithFakeLocation] for more information.
+	Location *time.Location
+}
+
+// DefaultFakeOptions returns a new [FakeOptions] with sane defaults.
+func DefaultFakeOptions() FakeOptions {
+	return FakeOptions{}
+}
+
+// With returns a new [FakeOptions] with opts merged on top of o.
+func (o FakeOptions) With(opts ...FakeOption) FakeOptions {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o FakeOptions) apply(opts *FakeOptions) {
+	if o.SynchronousCallbacks {
+		opts.SynchronousCallbacks = true
+	}
+
+	if o.FIFOCallbacks {
+		opts.FIFOCallbacks = true
+	}
+
+	if o.Drift != 0 {
+		opts.Drift = o.Drift
+	}
+
+	if o.TickerBuffer > 0 {
+		opts.TickerBuffer = o.TickerBuffer
+	}
+
+	if o.Location != nil {
+		opts.Location = o.Location
+	}
+}
+
+// A FakeOption configures a [FakeClock].
+type FakeOption interface {
+	apply(*FakeOptions)
+}
+
+type fakeOptionFunc func(*FakeOptions)
+
+func (f fakeOptionFunc) apply(o *FakeOptions) {
+	f(o)
+}
+
+// WithSynchronousCallbacks returns a [FakeOption] that configures a
+// [FakeClock] to run timer and ticker callbacks synchronously, in the order
+// that they are due, on the goroutine that advances the clock (e.g. via
+// [FakeClock.Add] or [FakeClock.SetNanotime]). Callbacks have all run by the
+// time that the call advancing the clock returns, which removes the need to
+// poll for their side effects in tests.
+//
+// Callbacks are run after the clock's internal lock has been released, so they
+// may safely use the clock (e.g. to schedule new timers). However, a callback
+// that blocks waiting for the clock to advance, such as by calling
+// [FakeClock.Sleep] or by waiting on a timer's channel, will deadlock: the
+// goroutine that would advance the clock is the one running the callback.
+func WithSynchronousCallbacks() FakeOption {
+	return fakeOptionFunc(func(o *FakeOptions) {
+		o.SynchronousCallbacks = true
+	})
+}
+
+// WithFIFOCallbacks returns a [FakeOption] that configures a [FakeClock] to run
+// timer and ticker callbacks one at a time, in the order that they are due, on
+// a goroutine separate from the one that advances the clock. Callbacks that are
+// due at the same time run in the order in which they were scheduled, and
+// callbacks made due by successive calls advancing the clock run in the order
+// of those calls. Unlike [WithSynchronousCallbacks], callbacks may block
+// waiting for the clock to advance, although doing so delays all subsequent
+// callbacks. If both options are given, [WithSynchronousCallbacks] takes
+// precedence.
+func WithFIFOCallbacks() FakeOption {
+	return fakeOptionFunc(func(o *FakeOptions) {
+		o.FIFOCallbacks = true
+	})
+}
+
+// WithFakeDrift returns a [FakeOption] that configures a [FakeClock] to drift
+// by the given ratio each time it is advanced: a call to [FakeClock.Add] with a
+// duration d advances the clock by d*(1+ratio) instead. The ratio may be
+// negative, in which case the clock runs slow. Nanotime, Now, and timer
+// scheduling all use the drifted time, which allows timers to be observed
+// firing early or late relative to the durations that were added.
+//
+// Drift only applies to relative adjustments; [FakeClock.SetTime] and
+// [FakeClock.SetNanotime] set the clock's time exactly.
+func WithFakeDrift(ratio float64) FakeOption {
+	return fakeOptionFunc(func(o *FakeOptions) {
+		o.Drift = ratio
+	})
+}
+
+// WithTickerBuffer returns a [FakeOption] that configures a [FakeClock] to
+// create tickers whose channels have capacity n, rather than 1. When the clock
+// is advanced by several periods at once, such a ticker receives a tick for
+// each elapsed period, timestamped at that period, until its channel is full;
+// further ticks are dropped. The ticker then remains aligned to its original
+// period.
+//
+// This intentionally diverges from [time.Ticker], which buffers at most one
+// tick, so that tests can observe every tick of a ticker without advancing the
+// clock one period at a time. Timers, and tickers that run callbacks, are
+// unaffected. If n <= 0, the option has no effect.
+func WithTickerBuffer(n int) FakeOption {
+	return fakeOptionFunc(func(o *FakeOptions) {
+		if n > 0 {
+			o.TickerBuffer = n
+		}
+	})
+}
+
+// WithFakeLocation returns a [FakeOption] that configures a [FakeClock] to
+// report time in loc from [FakeClock.Now], e.g. [time.UTC] for tests that
+// assert on formatted times and must not depend on the local time zone. Only
+// the location of the returned times is affected; the instants that they
+// represent, and times delivered by timers and tickers, are unchanged. Without
+// this option, Now reports local time. If loc is nil, the option has no
+// effect.
+func WithFakeLocation(loc *time.Location) FakeOption {
+	return fakeOptionFunc(func(o *FakeOptions) {
+		if loc != nil {
+			o.Location = loc
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+var _ Clock = (*frozenClock)(nil)
+
+type frozenClock struct {
+	fake *FakeClock
+}
+
+// NewFrozenClock returns a [Clock] whose time is always t. Because time never
+// passes, timers, tickers, and sleeps with positive durations never fire or
+// return, and contexts returned by DeadlineContext never expire unless their
+// deadline is not after t. Timers created with non-positive durations, via
+// After, AfterFunc, or NewTimer, fire immediately; resetting a timer never
+// causes it to fire.
+func NewFrozenClock(t time.Time) Clock {
+	fake := NewFakeClock()
+	fake.SetTime(t)
+	return &frozenClock{
+		fake: fake,
+	}
+}
+
+func (c *frozenClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C
+}
+
+func (c *frozenClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	timer := c.fake.AfterFunc(d, fn)
+	if d <= 0 {
+		timer.Stop()
+		go fn()
+	}
+	return timer
+}
+
+func (c *frozenClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+func (c *frozenClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return c.fake.DeadlineContext(parent, ns)
+}
+
+func (c *frozenClock) Nanotime() int64 {
+	return c.fake.Nanotime()
+}
+
+func (c *frozenClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return c.fake.NewAlignedTicker(d)
+}
+
+func (c *frozenClock) newTickerAfter(first time.Duration, d time.Duration) *Ticker {
+	return c.fake.newTickerAfter(first, d)
+}
+
+func (c *frozenClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *frozenClock) NewTicker(d time.Duration) *Ticker {
+	return c.fake.NewTicker(d)
+}
+
+func (c *frozenClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return c.fake.NewTickerFunc(d, fn)
+}
+
+func (c *frozenClock) NewTimer(d time.Duration) *Timer {
+	timer := c.fake.NewTimer(d)
+	if d <= 0 {
+		timer.Stop()
+		tick(timer.fake.ch, c.Nanotime())
+	}
+	return timer
+}
+
+func (c *frozenClock) Now() time.Time {
+	return c.fake.Now()
+}
+
+func (c *frozenClock) Since(t time.Time) time.Duration {
+	return c.fake.Since(t)
+}
+
+func (c *frozenClock) SinceNanotime(ns int64) time.Duration {
+	return c.fake.SinceNanotime(ns)
+}
+
+func (c *frozenClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.fake.Sleep(d)
+}
+
+func (c *frozenClock) SleepUntil(t time.Time) {
+	sleepUntil(c, t.UnixNano())
+}
+
+func (c *frozenClock) SleepUntilNanotime(ns int64) {
+	sleepUntil(c, ns)
+}
+
+func (c *frozenClock) Snapshot() (time.Time, int64) {
+	return c.fake.Snapshot()
+}
+
+func (c *frozenClock) Tick(d time.Duration) <-chan time.Time {
+	return c.fake.Tick(d)
+}
+
+func (c *frozenClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return c.fake.TickContext(ctx, d)
+}
+
+func (c *frozenClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return c.fake.TimeoutContext(parent, d)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestNewFrozenClock(t *testing.T) {
+	var (
+		now = time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+		clk = clock.NewFrozenClock(now)
+	)
+
+	require.True(t, now.Equal(clk.Now()))
+	require.Equal(t, now.UnixNano(), clk.Nanotime())
+	require.Equal(t, time.Hour, clk.Since(now.Add(-time.Hour)))
+	require.Equal(t, -time.Second, clk.SinceNanotime(now.Add(time.Second).UnixNano()))
+
+	stopwatch := clk.NewStopwatch()
+	time.Sleep(time.Millisecond)
+	require.Zero(t, stopwatch.Elapsed())
+	require.True(t, now.Equal(clk.Now()))
+}
+
+func TestNewFrozenClock_Timers(t *testing.T) {
+	var (
+		now    = time.Unix(0, int64(time.Hour))
+		clk    = clock.NewFrozenClock(now)
+		called = make(chan struct{})
+	)
+
+	// Non-positive durations fire immediately.
+	requireTimeIs(t, now.UnixNano(), requireTick(t, clk.After(0)))
+	requireTick(t, clk.NewTimer(-1).C)
+	clk.AfterFunc(0, func() {
+		close(called)
+	})
+	<-called
+	clk.Sleep(0)
+
+	// Positive durations never fire.
+	var (
+		timer  = clk.NewTimer(time.Nanosecond)
+		ticker = clk.NewTicker(time.Nanosecond)
+	)
+	defer ticker.Stop()
+
+	time.Sleep(10 * time.Millisecond)
+	requireNoTick(t, timer.C)
+	requireNoTick(t, ticker.C)
+	require.True(t, timer.Stop())
+
+	// Deadlines only expire if they are not after the frozen time.
+	ctx, cancel := clk.DeadlineContext(context.Background(), now.UnixNano())
+	defer cancel()
+	requireContextDone(t, ctx)
+
+	ctx, cancel = clk.DeadlineContext(context.Background(), now.UnixNano()+1)
+	defer cancel()
+	requireNotDone(t, ctx)
+}
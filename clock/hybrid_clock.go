@@ -0,0 +1,151 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+var _ Clock = (*hybridClock)(nil)
+
+// NewHybridClock returns a new [Clock] that measures intervals using the
+// system's monotonic time, but reports the current time using the system's
+// wall time: Nanotime, SinceNanotime, SleepUntilNanotime, DeadlineContext, and
+// stopwatches use [DefaultNanotimeFunc], while Now, Since, and SleepUntil use
+// [time.Now]. This suits services that need correct interval math as well as
+// human-readable timestamps, e.g. for logs.
+//
+// Note that, unlike other clocks, the times reported by Now and Nanotime are
+// intentionally not on the same epoch, so they must not be compared or mixed:
+// time.Unix(0, clk.Nanotime()) is not the current time. Snapshot pairs a wall
+// time with a monotonic time, both derived from a single monotonic reading:
+// the wall time is the wall time at which the clock was created plus the
+// monotonic time elapsed since, so it does not reflect adjustments made to the
+// system's wall clock after the clock was created. Aligned tickers are aligned
+// to wall time.
+func NewHybridClock() Clock {
+	c := &hybridClock{
+		nanotime: DefaultNanotimeFunc(),
+		now:      DefaultTimeFunc(),
+	}
+	c.origin, c.originNs = c.now(), c.nanotime()
+	return c
+}
+
+type hybridClock struct {
+	nanotime NanotimeFunc
+	now      TimeFunc
+	origin   time.Time // wall time at originNs
+	originNs int64
+}
+
+func (c *hybridClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (c *hybridClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	return newRuntimeTimer(d, fn)
+}
+
+func (c *hybridClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+func (c *hybridClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return runtimeDeadlineContext(parent, time.Duration(ns-c.Nanotime()))
+}
+
+func (c *hybridClock) Nanotime() int64 {
+	return c.nanotime()
+}
+
+func (c *hybridClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *hybridClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return newRuntimeAlignedTicker(d, untilBoundary(c.now().UnixNano(), d))
+}
+
+func (c *hybridClock) NewTicker(d time.Duration) *Ticker {
+	ticker := time.NewTicker(d)
+	return &Ticker{
+		C:      ticker.C,
+		ticker: ticker,
+	}
+}
+
+func (c *hybridClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return newRuntimeTickerFunc(d, fn)
+}
+
+func (c *hybridClock) NewTimer(d time.Duration) *Timer {
+	return newRuntimeTimer(d, nil)
+}
+
+func (c *hybridClock) Now() time.Time {
+	return c.now()
+}
+
+func (c *hybridClock) Since(t time.Time) time.Duration {
+	return c.now().Sub(t)
+}
+
+func (c *hybridClock) SinceNanotime(ns int64) time.Duration {
+	return time.Duration(c.Nanotime() - ns)
+}
+
+func (c *hybridClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (c *hybridClock) SleepUntil(t time.Time) {
+	time.Sleep(t.Sub(c.now()))
+}
+
+func (c *hybridClock) SleepUntilNanotime(ns int64) {
+	sleepUntil(c, ns)
+}
+
+func (c *hybridClock) Snapshot() (time.Time, int64) {
+	ns := c.nanotime()
+	return c.origin.Add(time.Duration(ns - c.originNs)), ns
+}
+
+func (c *hybridClock) Tick(d time.Duration) <-chan time.Time {
+	//nolint:staticcheck
+	return time.Tick(d)
+}
+
+func (c *hybridClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return tickContext(ctx, c.NewTicker(d))
+}
+
+func (c *hybridClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
@@ -21,6 +21,7 @@
 package clock
 
 import (
+	"context"
 	"time"
 )
 
@@ -41,19 +42,30 @@ func (c *monotonicClock) After(d time.Duration) <-chan time.Time {
 }
 
 func (c *monotonicClock) AfterFunc(d time.Duration, fn func()) *Timer {
-	x := time.AfterFunc(d, fn)
-	return &Timer{
-		C:     x.C,
-		timer: x,
-	}
+	return newRuntimeTimer(d, fn)
+}
+
+func (c *monotonicClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+func (c *monotonicClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return runtimeDeadlineContext(parent, time.Duration(ns-c.Nanotime()))
 }
 
 func (c *monotonicClock) Nanotime() int64 {
 	return c.fn()
 }
 
-func (c *monotonicClock) NewStopwatch() *Stopwatch {
-	return newStopwatch(c)
+func (c *monotonicClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *monotonicClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return newRuntimeAlignedTicker(d, untilBoundary(c.Nanotime(), d))
 }
 
 func (c *monotonicClock) NewTicker(d time.Duration) *Ticker {
@@ -64,12 +76,12 @@ func (c *monotonicClock) NewTicker(d time.Duration) *Ticker {
 	}
 }
 
+func (c *monotonicClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return newRuntimeTickerFunc(d, fn)
+}
+
 func (c *monotonicClock) NewTimer(d time.Duration) *Timer {
-	timer := time.NewTimer(d)
-	return &Timer{
-		C:     timer.C,
-		timer: timer,
-	}
+	return newRuntimeTimer(d, nil)
 }
 
 func (c *monotonicClock) Now() time.Time {
@@ -88,7 +100,31 @@ func (c *monotonicClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+func (c *monotonicClock) SleepUntil(t time.Time) {
+	sleepUntil(c, t.UnixNano())
+}
+
+func (c *monotonicClock) SleepUntilNanotime(ns int64) {
+	sleepUntil(c, ns)
+}
+
+func (c *monotonicClock) Snapshot() (time.Time, int64) {
+	ns := c.fn()
+	return time.Unix(0, ns), ns
+}
+
 func (c *monotonicClock) Tick(d time.Duration) <-chan time.Time {
 	//nolint:staticcheck
 	return time.Tick(d)
 }
+
+func (c *monotonicClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return tickContext(ctx, c.NewTicker(d))
+}
+
+func (c *monotonicClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
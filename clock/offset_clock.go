@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+var _ Clock = (*offsetClock)(nil)
+
+type offsetClock struct {
+	base   Clock
+	offset time.Duration
+}
+
+// WithOffset returns a [Clock] that reports base's time plus offset, e.g. for
+// simulating clock skew between nodes that share a base clock. Timers and
+// tickers are scheduled by base, so advancing a [FakeClock] base advances all
+// of its offset views. Durations are unaffected by the offset, while absolute
+// times, such as the deadline given to [Clock.DeadlineContext] and the
+// boundaries used by [Clock.NewAlignedTicker], are interpreted in the offset
+// view. Note that the times sent on timer and ticker channels are reported by
+// base, without the offset.
+func WithOffset(base Clock, offset time.Duration) Clock {
+	// Collapse nested offsets so that base is always an underlying clock.
+	if x, ok := base.(*offsetClock); ok {
+		return &offsetClock{
+			base:   x.base,
+			offset: x.offset + offset,
+		}
+	}
+
+	return &offsetClock{
+		base:   base,
+		offset: offset,
+	}
+}
+
+func (c *offsetClock) After(d time.Duration) <-chan time.Time {
+	return c.base.After(d)
+}
+
+func (c *offsetClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	return c.base.AfterFunc(d, fn)
+}
+
+func (c *offsetClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	c.base.AfterFuncContext(ctx, d, fn)
+}
+
+func (c *offsetClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return c.base.DeadlineContext(parent, ns-int64(c.offset))
+}
+
+func (c *offsetClock) Nanotime() int64 {
+	return c.base.Nanotime() + int64(c.offset)
+}
+
+func (c *offsetClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return newTickerAfter(c.base, untilBoundary(c.Nanotime(), d), d)
+}
+
+func (c *offsetClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *offsetClock) NewTicker(d time.Duration) *Ticker {
+	return c.base.NewTicker(d)
+}
+
+func (c *offsetClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return c.base.NewTickerFunc(d, fn)
+}
+
+func (c *offsetClock) NewTimer(d time.Duration) *Timer {
+	return c.base.NewTimer(d)
+}
+
+func (c *offsetClock) Now() time.Time {
+	return c.base.Now().Add(c.offset)
+}
+
+func (c *offsetClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *offsetClock) SinceNanotime(ns int64) time.Duration {
+	return time.Duration(c.Nanotime() - ns)
+}
+
+func (c *offsetClock) Sleep(d time.Duration) {
+	c.base.Sleep(d)
+}
+
+func (c *offsetClock) SleepUntil(t time.Time) {
+	c.SleepUntilNanotime(t.UnixNano())
+}
+
+func (c *offsetClock) SleepUntilNanotime(ns int64) {
+	c.base.SleepUntilNanotime(ns - int64(c.offset))
+}
+
+func (c *offsetClock) Snapshot() (time.Time, int64) {
+	now, ns := c.base.Snapshot()
+	return now.Add(c.offset), ns + int64(c.offset)
+}
+
+func (c *offsetClock) Tick(d time.Duration) <-chan time.Time {
+	return c.base.Tick(d)
+}
+
+func (c *offsetClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return c.base.TickContext(ctx, d)
+}
+
+func (c *offsetClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return c.base.TimeoutContext(parent, d)
+}
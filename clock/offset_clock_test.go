@@ -0,0 +1,142 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestWithOffset(t *testing.T) {
+	var (
+		base   = clock.NewFakeClock()
+		ahead  = clock.WithOffset(base, time.Minute)
+		behind = clock.WithOffset(base, -time.Minute)
+	)
+
+	base.SetNanotime(int64(time.Hour))
+	require.Equal(t, int64(time.Hour+time.Minute), ahead.Nanotime())
+	require.Equal(t, int64(time.Hour-time.Minute), behind.Nanotime())
+	require.Equal(t, base.Now().Add(time.Minute), ahead.Now())
+	require.Equal(t, base.Now().Add(-time.Minute), behind.Now())
+
+	// Advancing the base advances every view.
+	base.Add(time.Second)
+	require.Equal(t, int64(time.Hour+time.Minute+time.Second), ahead.Nanotime())
+	require.Equal(t, int64(time.Hour-time.Minute+time.Second), behind.Nanotime())
+
+	// Elapsed time is measured in the offset view.
+	require.Equal(t, time.Minute, ahead.Since(base.Now()))
+	require.Equal(t, -time.Minute, behind.SinceNanotime(base.Nanotime()))
+
+	// Nested offsets are combined.
+	require.Equal(
+		t,
+		base.Nanotime(),
+		clock.WithOffset(ahead, -time.Minute).Nanotime(),
+	)
+}
+
+func TestWithOffset_Timers(t *testing.T) {
+	var (
+		base   = clock.NewFakeClock()
+		offset = clock.WithOffset(base, 30*time.Second)
+		timer  = offset.NewTimer(time.Second)
+		ticker = offset.NewTicker(time.Second)
+		after  = offset.After(time.Second)
+	)
+	defer ticker.Stop()
+
+	// Timers are scheduled by durations, which are unaffected by the offset.
+	base.Add(time.Second)
+	requireTick(t, timer.C)
+	requireTick(t, ticker.C)
+	requireTick(t, after)
+
+	// Deadlines are interpreted in the offset view.
+	ctx, cancel := offset.DeadlineContext(
+		context.Background(),
+		offset.Nanotime()+int64(time.Second),
+	)
+	defer cancel()
+	require.NoError(t, ctx.Err())
+	base.Add(time.Second)
+	requireContextDone(t, ctx)
+
+	// Aligned ticks are aligned to the offset view: the offset view is at
+	// 32s, so the next minute boundary is 28s away.
+	aligned := offset.NewAlignedTicker(time.Minute)
+	defer aligned.Stop()
+	base.Add(27 * time.Second)
+	requireNoTick(t, aligned.C)
+	base.Add(time.Second)
+	requireTick(t, aligned.C)
+}
+
+func TestWithOffset_AlignedTicker_FakeBases(t *testing.T) {
+	newFake := func() *clock.FakeClock {
+		clk := clock.NewFakeClock()
+		clk.SetNanotime(int64(30 * time.Second))
+		return clk
+	}
+
+	cases := map[string]func() (clock.Clock, *clock.FakeClock){
+		"fake": func() (clock.Clock, *clock.FakeClock) {
+			fake := newFake()
+			return fake, fake
+		},
+		"trace": func() (clock.Clock, *clock.FakeClock) {
+			trace := clock.NewReplayClock([]int64{int64(30 * time.Second)})
+			return trace, trace.FakeClock
+		},
+		"tracing": func() (clock.Clock, *clock.FakeClock) {
+			fake := newFake()
+			tracing, _ := clock.NewTracingClock(fake)
+			return tracing, fake
+		},
+		"switchable": func() (clock.Clock, *clock.FakeClock) {
+			fake := newFake()
+			return clock.NewSwitchableClock(fake), fake
+		},
+	}
+
+	for name, newBase := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				base, fake = newBase()
+				offset     = clock.WithOffset(base, 2*time.Second)
+			)
+
+			// The offset view is at 32s, so the next minute boundary is 28s
+			// away in fake time.
+			aligned := offset.NewAlignedTicker(time.Minute)
+			defer aligned.Stop()
+			fake.Add(27 * time.Second)
+			requireNoTick(t, aligned.C)
+			fake.Add(time.Second)
+			requireTick(t, aligned.C)
+		})
+	}
+}
@@ -21,9 +21,11 @@
 package clock
 
 import (
+	"math"
 	"time"
 
 	"go.mway.dev/chrono"
+	"go.uber.org/atomic"
 )
 
 // A TimeFunc is a function that returns time as a [time.Time] object.
@@ -43,6 +45,26 @@ func DefaultNanotimeFunc() NanotimeFunc {
 	return chrono.Nanotime
 }
 
+// NondecreasingNanotimeFunc returns a new [NanotimeFunc] that reports the time
+// given by fn, clamped such that it never decreases: if fn reports a time that
+// is earlier than a time it previously reported, the latest time seen so far is
+// returned instead. The returned func is safe for concurrent use.
+func NondecreasingNanotimeFunc(fn NanotimeFunc) NanotimeFunc {
+	latest := atomic.NewInt64(math.MinInt64)
+	return func() int64 {
+		now := fn()
+		for {
+			prev := latest.Load()
+			if now <= prev {
+				return prev
+			}
+			if latest.CAS(prev, now) {
+				return now
+			}
+		}
+	}
+}
+
 // Options configure a [Clock].
 type Options struct {
 	// TimeFunc configures the [TimeFunc] for a [Clock].
@@ -51,6 +73,13 @@ type Options struct {
 	// NanotimeFunc configures the [NanotimeFunc] for a [Clock].
 	// If both TimeFunc and NanotimeFunc are provided, NanotimeFunc is used.
 	NanotimeFunc NanotimeFunc
+	// Location configures the [time.Location] in which a wall [Clock] (i.e.
+	// one that uses a TimeFunc) reports time. If nil, times are reported as
+	// given by the TimeFunc.
+	Location *time.Location
+	// Resolution configures a [Clock] to report time truncated to a multiple
+	// of the given duration. See [WithResolution] for more information.
+	Resolution time.Duration
 }
 
 // DefaultOptions returns a new [Options] with sane defaults.
@@ -68,6 +97,14 @@ func (o Options) apply(opts *Options) {
 	if o.NanotimeFunc != nil {
 		opts.NanotimeFunc = o.NanotimeFunc
 	}
+
+	if o.Location != nil {
+		opts.Location = o.Location
+	}
+
+	if o.Resolution > 0 {
+		opts.Resolution = o.Resolution
+	}
 }
 
 // An Option configures a Clock.
@@ -98,3 +135,39 @@ func WithTimeFunc(f TimeFunc) Option {
 		o.NanotimeFunc = nil
 	})
 }
+
+// WithLocation returns an [Option] that configures a wall [Clock] to report
+// time in loc. Only the location of times returned by the clock is affected;
+// the instants that they represent, and thus the clock's nanotime, are
+// unchanged. This option has no effect on clocks that use a [NanotimeFunc].
+func WithLocation(loc *time.Location) Option {
+	return optionFunc(func(o *Options) {
+		o.Location = loc
+	})
+}
+
+// WithResolution returns an [Option] that configures a [Clock] to report time
+// truncated to a multiple of d since the Unix epoch, e.g. for systems that
+// cannot handle nanosecond precision. Unlike a [ThrottledClock], the clock
+// still reads its time function on every call; only the precision of the
+// reported time changes. Because both Nanotime and Now are truncated, deadlines
+// and aligned tickers are computed relative to the truncated time, while
+// durations given to timers and tickers are measured as usual. If d <= 0, the
+// option has no effect.
+func WithResolution(d time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		if d > 0 {
+			o.Resolution = d
+		}
+	})
+}
+
+// truncateNanotime returns ns truncated to a multiple of d, rounding toward
+// negative infinity.
+func truncateNanotime(ns int64, d time.Duration) int64 {
+	rem := ns % int64(d)
+	if rem < 0 {
+		rem += int64(d)
+	}
+	return ns - rem
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+var _ Clock = (*steppingClock)(nil)
+
+type steppingClock struct {
+	fake *FakeClock
+	step int64
+}
+
+// NewSteppingClock returns a [Clock] that advances by step every time that it
+// is asked for the time: successive calls to Nanotime return start,
+// start+step, start+2*step, and so on, including across goroutines. Now,
+// Since, SinceNanotime, and stopwatches each read the time exactly once, and
+// so also advance the clock. This gives deterministic, strictly increasing
+// (for positive step) timestamps without depending on the resolution of the
+// system clock, e.g. for benchmarks.
+//
+// Timers, tickers, and contexts returned by DeadlineContext are scheduled
+// relative to the next time that the clock will report, and fire once the
+// clock has reported a time at or after their deadline. Sleep does not block;
+// it advances the clock by d instead.
+func NewSteppingClock(start int64, step time.Duration) Clock {
+	fake := NewFakeClock()
+	fake.SetNanotime(start)
+	return &steppingClock{
+		fake: fake,
+		step: int64(step),
+	}
+}
+
+func (c *steppingClock) After(d time.Duration) <-chan time.Time {
+	return c.fake.After(d)
+}
+
+func (c *steppingClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	return c.fake.AfterFunc(d, fn)
+}
+
+func (c *steppingClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	c.fake.AfterFuncContext(ctx, d, fn)
+}
+
+func (c *steppingClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return c.fake.DeadlineContext(parent, ns)
+}
+
+func (c *steppingClock) Nanotime() int64 {
+	ns := c.fake.shared.now.Add(c.step) - c.step
+	c.fake.shared.checkTimers(ns)
+	return ns
+}
+
+func (c *steppingClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return c.fake.NewAlignedTicker(d)
+}
+
+func (c *steppingClock) newTickerAfter(first time.Duration, d time.Duration) *Ticker {
+	return c.fake.newTickerAfter(first, d)
+}
+
+func (c *steppingClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *steppingClock) NewTicker(d time.Duration) *Ticker {
+	return c.fake.NewTicker(d)
+}
+
+func (c *steppingClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return c.fake.NewTickerFunc(d, fn)
+}
+
+func (c *steppingClock) NewTimer(d time.Duration) *Timer {
+	return c.fake.NewTimer(d)
+}
+
+func (c *steppingClock) Now() time.Time {
+	return time.Unix(0, c.Nanotime())
+}
+
+func (c *steppingClock) Since(t time.Time) time.Duration {
+	return c.SinceNanotime(t.UnixNano())
+}
+
+func (c *steppingClock) SinceNanotime(ns int64) time.Duration {
+	return time.Duration(c.Nanotime() - ns)
+}
+
+func (c *steppingClock) Sleep(d time.Duration) {
+	c.fake.Add(d)
+}
+
+func (c *steppingClock) SleepUntil(t time.Time) {
+	sleepUntil(c, t.UnixNano())
+}
+
+func (c *steppingClock) SleepUntilNanotime(ns int64) {
+	sleepUntil(c, ns)
+}
+
+func (c *steppingClock) Snapshot() (time.Time, int64) {
+	ns := c.Nanotime()
+	return time.Unix(0, ns), ns
+}
+
+func (c *steppingClock) Tick(d time.Duration) <-chan time.Time {
+	return c.fake.Tick(d)
+}
+
+func (c *steppingClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return c.fake.TickContext(ctx, d)
+}
+
+func (c *steppingClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return c.fake.TimeoutContext(parent, d)
+}
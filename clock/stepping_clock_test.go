@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestNewSteppingClock(t *testing.T) {
+	clk := clock.NewSteppingClock(100, time.Second)
+
+	for i := int64(0); i < 3; i++ {
+		require.Equal(t, 100+i*int64(time.Second), clk.Nanotime())
+	}
+	requireTimeIs(t, 100+3*int64(time.Second), clk.Now())
+	require.Equal(t, 4*time.Second, clk.SinceNanotime(100))
+	require.Equal(t, 5*time.Second, clk.Since(time.Unix(0, 100)))
+
+	// Sleeping advances the clock without blocking.
+	clk.Sleep(time.Hour)
+	require.Equal(t, 100+6*int64(time.Second)+int64(time.Hour), clk.Nanotime())
+
+	// Each stopwatch read advances the clock by one step.
+	stopwatch := clk.NewStopwatch()
+	require.Equal(t, time.Second, stopwatch.Elapsed())
+	require.Equal(t, 2*time.Second, stopwatch.Elapsed())
+}
+
+func TestNewSteppingClock_SleepUntil(t *testing.T) {
+	clk := clock.NewSteppingClock(0, time.Second)
+
+	// Sleeping advances the clock to the target without blocking.
+	clk.SleepUntilNanotime(int64(10 * time.Second))
+	require.EqualValues(t, 11*time.Second, clk.Nanotime())
+
+	// Targets in the past do not move the clock backward.
+	clk.SleepUntil(time.Unix(0, 0))
+	require.EqualValues(t, 13*time.Second, clk.Nanotime())
+}
+
+func TestNewSteppingClock_Concurrent(t *testing.T) {
+	var (
+		clk  = clock.NewSteppingClock(0, 1)
+		seen = make([][]int64, 8)
+		wg   sync.WaitGroup
+	)
+
+	for i := range seen {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				seen[i] = append(seen[i], clk.Nanotime())
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every call observes a distinct time.
+	unique := make(map[int64]struct{})
+	for _, times := range seen {
+		for _, ns := range times {
+			unique[ns] = struct{}{}
+		}
+	}
+	require.Len(t, unique, 8000)
+}
+
+func TestNewSteppingClock_Timers(t *testing.T) {
+	var (
+		clk    = clock.NewSteppingClock(0, time.Second)
+		timer  = clk.NewTimer(3 * time.Second)
+		ticker = clk.NewTicker(2 * time.Second)
+	)
+	defer ticker.Stop()
+
+	// Timers fire once the clock has reported their deadlines.
+	for i := 0; i < 3; i++ {
+		clk.Nanotime()
+	}
+	requireNoTick(t, timer.C)
+	requireTimeIs(t, int64(2*time.Second), requireTick(t, ticker.C))
+	clk.Nanotime()
+	requireTimeIs(t, int64(3*time.Second), requireTick(t, timer.C))
+}
@@ -24,31 +24,124 @@ import "time"
 
 // A Stopwatch measures elapsed time. A Stopwatch is created by calling
 // [Clock.NewStopwatch].
+//
+// By default, a Stopwatch starts running when it is created, using the clock's
+// time at creation as its epoch. A Stopwatch created with [WithStopped] instead
+// reports zero elapsed time until [Stopwatch.Start] is called. A running
+// Stopwatch may be stopped with [Stopwatch.Stop], after which it reports the
+// time elapsed up until it was stopped.
+//
+// A Stopwatch reports durations exactly as measured by its clock. If the clock
+// can move backward, as wall clocks can, reported durations may be negative
+// unless the Stopwatch is created with [WithMonotonicGuard].
 type Stopwatch struct {
-	clock Clock
-	epoch int64
+	clock   Clock
+	epoch   int64
+	split   int64
+	stopped int64
+	running bool
+	guard   bool
 }
 
-func newStopwatch(clk Clock) *Stopwatch {
+func newStopwatch(clk Clock, opts ...StopwatchOption) *Stopwatch {
+	var (
+		options = DefaultStopwatchOptions().With(opts...)
+		now     = clk.Nanotime()
+	)
+
 	return &Stopwatch{
-		clock: clk,
-		epoch: clk.Nanotime(),
+		clock:   clk,
+		epoch:   now,
+		split:   now,
+		stopped: now,
+		running: !options.Stopped,
+		guard:   options.MonotonicGuard,
 	}
 }
 
-// Elapsed returns the time elapsed since the last call to [Stopwatch.Reset].
+// Elapsed returns the time elapsed since the last call to [Stopwatch.Reset] or
+// [Stopwatch.Start]. If the stopwatch is stopped, Elapsed returns the time that
+// had elapsed when it was stopped.
 func (s *Stopwatch) Elapsed() time.Duration {
-	return time.Duration(s.clock.Nanotime() - s.epoch)
+	return s.since(s.epoch, s.now())
+}
+
+// ElapsedSince returns the time elapsed since ns according to the stopwatch's
+// clock. It does not use or change the stopwatch's epoch.
+func (s *Stopwatch) ElapsedSince(ns int64) time.Duration {
+	return time.Duration(s.clock.Nanotime() - ns)
+}
+
+// ElapsedSinceTime returns the time elapsed since t according to the
+// stopwatch's clock. It is shorthand for s.ElapsedSince(t.UnixNano()).
+func (s *Stopwatch) ElapsedSinceTime(t time.Time) time.Duration {
+	return s.ElapsedSince(t.UnixNano())
 }
 
 // Reset resets the stopwatch to zero, returning the elapsed time since the
-// last call to Reset.
+// last call to Reset. Reset does not change whether the stopwatch is running.
 func (s *Stopwatch) Reset() time.Duration {
 	var (
-		now     = s.clock.Nanotime()
-		elapsed = time.Duration(now - s.epoch)
+		now     = s.now()
+		elapsed = s.since(s.epoch, now)
 	)
 
 	s.epoch = now
+	s.split = now
 	return elapsed
 }
+
+// Running returns whether the stopwatch is running.
+func (s *Stopwatch) Running() bool {
+	return s.running
+}
+
+// Split returns the time elapsed since the last call to [Stopwatch.Reset] as
+// total, and the time elapsed since the previous call to Split (or Reset, if
+// Split has not been called since) as sinceLast. Unlike Reset, Split does not
+// change the stopwatch's epoch.
+func (s *Stopwatch) Split() (total time.Duration, sinceLast time.Duration) {
+	now := s.now()
+	total = s.since(s.epoch, now)
+	sinceLast = s.since(s.split, now)
+	s.split = now
+	return total, sinceLast
+}
+
+// Start sets the stopwatch's epoch to the clock's current time and marks it as
+// running, discarding any previously elapsed time. Start may be called whether
+// or not the stopwatch is already running.
+func (s *Stopwatch) Start() {
+	now := s.clock.Nanotime()
+	s.epoch = now
+	s.split = now
+	s.running = true
+}
+
+// Stop stops the stopwatch, returning the time elapsed since the last call to
+// [Stopwatch.Reset] or [Stopwatch.Start]. The returned value continues to be
+// reported by [Stopwatch.Elapsed] until the stopwatch is started again. Calling
+// Stop on a stopped stopwatch has no effect.
+func (s *Stopwatch) Stop() time.Duration {
+	if s.running {
+		s.stopped = s.clock.Nanotime()
+		s.running = false
+	}
+	return s.since(s.epoch, s.stopped)
+}
+
+// since returns the time elapsed between then and now, clamped to zero if the
+// stopwatch was created with [WithMonotonicGuard].
+func (s *Stopwatch) since(then int64, now int64) time.Duration {
+	if s.guard && now < then {
+		return 0
+	}
+	return time.Duration(now - then)
+}
+
+func (s *Stopwatch) now() int64 {
+	if !s.running {
+		return s.stopped
+	}
+	return s.clock.Nanotime()
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+// StopwatchOptions configure a [Stopwatch].
+type StopwatchOptions struct {
+	// Stopped configures whether a [Stopwatch] is created stopped, rather
+	// than running. See [WithStopped] for more information.
+	Stopped bool
+	// MonotonicGuard configures whether a [Stopwatch] clamps the durations
+	// that it reports to zero. See [WithMonotonicGuard] for more
+	// information.
+	MonotonicGuard bool
+}
+
+// DefaultStopwatchOptions returns a new [StopwatchOptions] with sane defaults.
+func DefaultStopwatchOptions() StopwatchOptions {
+	return StopwatchOptions{}
+}
+
+// With returns a new [StopwatchOptions] with opts merged on top of o.
+func (o StopwatchOptions) With(opts ...StopwatchOption) StopwatchOptions {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o StopwatchOptions) apply(opts *StopwatchOptions) {
+	if o.Stopped {
+		opts.Stopped = true
+	}
+
+	if o.MonotonicGuard {
+		opts.MonotonicGuard = true
+	}
+}
+
+// A StopwatchOption configures a [Stopwatch].
+type StopwatchOption interface {
+	apply(*StopwatchOptions)
+}
+
+type stopwatchOptionFunc func(*StopwatchOptions)
+
+func (f stopwatchOptionFunc) apply(o *StopwatchOptions) {
+	f(o)
+}
+
+// WithStopped returns a [StopwatchOption] that configures a [Stopwatch] to be
+// created stopped: it reports zero elapsed time until [Stopwatch.Start] is
+// called, at which point its epoch is set to the clock's time.
+func WithStopped() StopwatchOption {
+	return stopwatchOptionFunc(func(o *StopwatchOptions) {
+		o.Stopped = true
+	})
+}
+
+// WithMonotonicGuard returns a [StopwatchOption] that configures a [Stopwatch]
+// to never report negative durations: if its clock moves backward, e.g.
+// because NTP stepped a wall clock, [Stopwatch.Elapsed] and the durations
+// returned by Reset, Split, and Stop are clamped to zero rather than leaking
+// negative values into metrics. The stopwatch's epoch is not adjusted, so time
+// is reported again once the clock has caught back up. [Stopwatch.ElapsedSince]
+// and [Stopwatch.ElapsedSinceTime] do not use the epoch and are unaffected.
+func WithMonotonicGuard() StopwatchOption {
+	return stopwatchOptionFunc(func(o *StopwatchOptions) {
+		o.MonotonicGuard = true
+	})
+}
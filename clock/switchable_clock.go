@@ -0,0 +1,206 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+var _ Clock = (*SwitchableClock)(nil)
+
+// A SwitchableClock is a [Clock] that delegates to a source clock that can be
+// swapped at runtime, e.g. to switch a service between real time and a replayed
+// time source without reconstructing everything that depends on its clock. A
+// SwitchableClock is safe for concurrent use.
+//
+// Each call is routed to the source at the time of the call. Timers, tickers,
+// and contexts that were created before a swap keep the source that created
+// them, while stopwatches read the current source each time that they are
+// used.
+type SwitchableClock struct {
+	source atomic.Value // clockSource
+}
+
+// clockSource wraps a [Clock] so that sources of differing concrete types can
+// be stored in the same [atomic.Value].
+type clockSource struct {
+	Clock
+}
+
+// NewSwitchableClock returns a new [SwitchableClock] that initially delegates
+// to initial. If initial is nil, the shared monotonic clock returned by
+// [Monotonic] is used.
+func NewSwitchableClock(initial Clock) *SwitchableClock {
+	c := &SwitchableClock{}
+	if initial == nil {
+		initial = Monotonic()
+	}
+	c.SetSource(initial)
+	return c
+}
+
+// SetSource atomically replaces the clock to which c delegates with src. If src
+// is nil, SetSource has no effect.
+func (c *SwitchableClock) SetSource(src Clock) {
+	if src == nil {
+		return
+	}
+	c.source.Store(clockSource{src})
+}
+
+// Source returns the clock to which c currently delegates.
+func (c *SwitchableClock) Source() Clock {
+	return c.current()
+}
+
+// After returns a channel that receives the current time after d has elapsed,
+// as measured by the current source.
+func (c *SwitchableClock) After(d time.Duration) <-chan time.Time {
+	return c.current().After(d)
+}
+
+// AfterFunc returns a timer, created by the current source, that will invoke
+// the given function after d has elapsed.
+func (c *SwitchableClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	return c.current().AfterFunc(d, fn)
+}
+
+// AfterFuncContext is like [SwitchableClock.AfterFunc], but fn is not called if
+// ctx is done before d has elapsed.
+func (c *SwitchableClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	c.current().AfterFuncContext(ctx, d, fn)
+}
+
+// DeadlineContext returns a copy of parent that is canceled once the current
+// source's time reaches ns.
+func (c *SwitchableClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return c.current().DeadlineContext(parent, ns)
+}
+
+// Nanotime returns the current source's time in nanoseconds.
+func (c *SwitchableClock) Nanotime() int64 {
+	return c.current().Nanotime()
+}
+
+// NewAlignedTicker returns a new aligned [Ticker] created by the current
+// source.
+func (c *SwitchableClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return c.current().NewAlignedTicker(d)
+}
+
+func (c *SwitchableClock) newTickerAfter(first time.Duration, d time.Duration) *Ticker {
+	return newTickerAfter(c.current(), first, d)
+}
+
+// NewStopwatch returns a new [Stopwatch] that uses c for measuring time, and
+// so always reads the current source.
+func (c *SwitchableClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+// NewTicker returns a new [Ticker] created by the current source.
+func (c *SwitchableClock) NewTicker(d time.Duration) *Ticker {
+	return c.current().NewTicker(d)
+}
+
+// NewTickerFunc returns a new [Ticker], created by the current source, that
+// calls fn every d.
+func (c *SwitchableClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return c.current().NewTickerFunc(d, fn)
+}
+
+// NewTimer returns a new [Timer] created by the current source.
+func (c *SwitchableClock) NewTimer(d time.Duration) *Timer {
+	return c.current().NewTimer(d)
+}
+
+// Now returns the current source's time.
+func (c *SwitchableClock) Now() time.Time {
+	return c.current().Now()
+}
+
+// Since returns the amount of time that has elapsed since t, as measured by
+// the current source.
+func (c *SwitchableClock) Since(t time.Time) time.Duration {
+	return c.current().Since(t)
+}
+
+// SinceNanotime returns the amount of time that has elapsed since ns, as
+// measured by the current source.
+func (c *SwitchableClock) SinceNanotime(ns int64) time.Duration {
+	return c.current().SinceNanotime(ns)
+}
+
+// Sleep pauses the current goroutine for at least d, as measured by the
+// current source.
+func (c *SwitchableClock) Sleep(d time.Duration) {
+	c.current().Sleep(d)
+}
+
+// SleepUntil pauses the current goroutine until the current source's time
+// reaches t.
+func (c *SwitchableClock) SleepUntil(t time.Time) {
+	c.current().SleepUntil(t)
+}
+
+// SleepUntilNanotime pauses the current goroutine until the current source's
+// time reaches ns.
+func (c *SwitchableClock) SleepUntilNanotime(ns int64) {
+	c.current().SleepUntilNanotime(ns)
+}
+
+// Snapshot returns the current source's time both as a [time.Time] and as
+// integer nanoseconds, derived from a single reading of the source.
+func (c *SwitchableClock) Snapshot() (time.Time, int64) {
+	return c.current().Snapshot()
+}
+
+// Tick returns a channel that receives ticks every d from a ticker created by
+// the current source.
+func (c *SwitchableClock) Tick(d time.Duration) <-chan time.Time {
+	return c.current().Tick(d)
+}
+
+// TickContext is like [SwitchableClock.Tick], but the underlying ticker is
+// stopped and the returned channel is closed once ctx is done.
+func (c *SwitchableClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return c.current().TickContext(ctx, d)
+}
+
+// TimeoutContext returns a copy of parent that is canceled once d has elapsed,
+// as measured by the current source.
+func (c *SwitchableClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return c.current().TimeoutContext(parent, d)
+}
+
+func (c *SwitchableClock) current() Clock {
+	//nolint:errcheck
+	return c.source.Load().(clockSource).Clock
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestSwitchableClock(t *testing.T) {
+	var (
+		live   = clock.NewFakeClock()
+		replay = clock.NewFakeClock()
+		clk    = clock.NewSwitchableClock(live)
+	)
+
+	live.SetNanotime(100)
+	replay.SetNanotime(200)
+	require.Same(t, live, clk.Source())
+	require.EqualValues(t, 100, clk.Nanotime())
+
+	stopwatch := clk.NewStopwatch()
+	timer := clk.NewTimer(time.Second)
+
+	clk.SetSource(replay)
+	clk.SetSource(nil)
+	require.Same(t, replay, clk.Source())
+	requireTimeIs(t, 200, clk.Now())
+	now, ns := clk.Snapshot()
+	requireTimeIs(t, 200, now)
+	require.EqualValues(t, 200, ns)
+
+	// Stopwatches follow the current source.
+	require.EqualValues(t, 100, stopwatch.Elapsed())
+
+	// Timers keep the source that created them.
+	replay.Add(time.Second)
+	requireNoTick(t, timer.C)
+	live.Add(time.Second)
+	requireTick(t, timer.C)
+
+	// New timers use the new source.
+	timer = clk.NewTimer(time.Second)
+	replay.Add(time.Second)
+	requireTick(t, timer.C)
+}
+
+func TestSwitchableClock_Default(t *testing.T) {
+	clk := clock.NewSwitchableClock(nil)
+	require.Same(t, clock.Monotonic(), clk.Source())
+}
+
+func TestSwitchableClock_Concurrent(t *testing.T) {
+	var (
+		sources = []clock.Clock{
+			clock.NewFakeClock(),
+			clock.NewMonotonicClock(),
+			clock.NewFrozenClock(time.Unix(0, 0)),
+		}
+		clk = clock.NewSwitchableClock(sources[0])
+		wg  sync.WaitGroup
+	)
+
+	for i := 0; i < 4; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if i == 0 {
+					clk.SetSource(sources[j%len(sources)])
+					continue
+				}
+				clk.Nanotime()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
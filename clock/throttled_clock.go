@@ -21,14 +21,27 @@
 package clock
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"time"
 
+	"go.mway.dev/chrono"
 	"go.uber.org/atomic"
 )
 
 var _ Clock = (*ThrottledClock)(nil)
 
+const (
+	// _adaptiveInitialInterval is the interval at which an adaptive
+	// ThrottledClock initially updates its internal time.
+	_adaptiveInitialInterval = time.Millisecond
+	// _adaptiveMinInterval and _adaptiveMaxInterval bound the interval of an
+	// adaptive ThrottledClock.
+	_adaptiveMinInterval = 100 * time.Microsecond
+	_adaptiveMaxInterval = 100 * time.Millisecond
+)
+
 // DefaultWallNanotimeFunc returns a new, default [NanotimeFunc] that reports wall
 // time as nanoseconds.
 func DefaultWallNanotimeFunc() NanotimeFunc {
@@ -41,32 +54,85 @@ func DefaultWallNanotimeFunc() NanotimeFunc {
 // within a given threshold.
 type ThrottledClock struct {
 	nowfn    NanotimeFunc
+	sched    atomic.Value // *FakeClock, created by the first AfterFuncClock
+	schedMu  sync.Mutex   // serializes creating and advancing sched
 	done     chan struct{}
 	now      atomic.Int64
+	updates  atomic.Int64
+	reads    atomic.Int64
+	counting bool // whether reads are counted; see WithReadCounting
 	stopped  atomic.Bool
-	interval time.Duration
+	interval atomic.Duration
+	updated  atomic.Int64  // runtime nanotime of the last update
+	maxStale time.Duration // see WithMaxStaleness
+	stale    atomic.Bool   // whether a read is updating a stale clock
+	target   float64       // adaptive clocks only
+	seen     int64         // reads seen by the last adaptation
 	wg       sync.WaitGroup
 }
 
 // NewThrottledClock creates a new ThrottledClock that uses the given NanoFunc
-// to update its internal time at the given interval. A ThrottledClock should
-// be stopped via ThrottledClock.Stop once it is no longer used.
+// to update its internal time at the given interval, configured by the given
+// options. A ThrottledClock should be stopped via ThrottledClock.Stop once it
+// is no longer used.
 //
 // Note that interval should be tuned to be greater than the actual frequency
 // of calls to ThrottledClock.Nanos or ThrottledClock.Now (otherwise the clock
-// will generate more time calls than it is saving).
+// will generate more time calls than it is saving). ThrottledClock.Stats can be
+// used to measure the actual savings, provided that reads are counted (see
+// WithReadCounting). As a rule of thumb, an interval of 1ms (see
+// NewThrottledMonotonicClockMillis) suits callers that read the time tens of
+// thousands of times per second or more and can tolerate a millisecond of
+// staleness; callers that read the time less often should use a proportionally
+// longer interval, or NewAdaptiveThrottledClock.
 func NewThrottledClock(
 	nowfn NanotimeFunc,
 	interval time.Duration,
+	opts ...ThrottledOption,
+) *ThrottledClock {
+	return newThrottledClock(nowfn, interval, 0, opts...)
+}
+
+// NewAdaptiveThrottledClock creates a new ThrottledClock that uses the given
+// NanotimeFunc to update its internal time, tuning its update interval to keep
+// the ratio of updates to reads (see ThrottledClock.Stats) near target. For
+// example, a target of 0.01 aims for one update per 100 reads: as reads become
+// more frequent, the interval shortens, and as they become less frequent, it
+// lengthens. The interval starts at 1ms, changes by at most a factor of two per
+// update, and is bounded between 100µs and 100ms. ThrottledClock.Interval
+// reports the current interval.
+//
+// If target is not greater than zero and at most one, NewAdaptiveThrottledClock
+// will panic. See NewThrottledClock for more information.
+func NewAdaptiveThrottledClock(
+	nowfn NanotimeFunc,
+	target float64,
+	opts ...ThrottledOption,
+) *ThrottledClock {
+	if !(target > 0 && target <= 1) {
+		panic(errors.New("invalid target ratio for NewAdaptiveThrottledClock"))
+	}
+	return newThrottledClock(nowfn, _adaptiveInitialInterval, target, opts...)
+}
+
+func newThrottledClock(
+	nowfn NanotimeFunc,
+	interval time.Duration,
+	target float64,
+	opts ...ThrottledOption,
 ) *ThrottledClock {
+	options := DefaultThrottledOptions().With(opts...)
 	c := &ThrottledClock{
 		nowfn:    nowfn,
 		done:     make(chan struct{}),
-		interval: interval,
+		maxStale: options.MaxStaleness,
+		counting: options.CountReads || target > 0,
+		target:   target,
 	}
+	c.interval.Store(interval)
 
 	// Set the clock to an initial time value.
-	c.now.Store(c.nowfn())
+	c.update()
 
 	c.wg.Add(1)
 	go func() {
@@ -77,17 +143,49 @@ func NewThrottledClock(
 	return c
 }
 
+// NewThrottledClockFrom creates a new ThrottledClock that uses src to update
+// its internal time at the given interval. This allows an existing Clock (for
+// example, a FakeClock) to be throttled while remaining the system's single
+// source of time. See NewThrottledClock for more information.
+func NewThrottledClockFrom(
+	src Clock,
+	interval time.Duration,
+	opts ...ThrottledOption,
+) *ThrottledClock {
+	return NewThrottledClock(src.Nanotime, interval, opts...)
+}
+
 // NewThrottledMonotonicClock creates a new ThrottledClock that uses
 // NewMonotonicNanoFunc as its backing time function. See NewThrottledClock for
 // more information.
-func NewThrottledMonotonicClock(interval time.Duration) *ThrottledClock {
-	return NewThrottledClock(DefaultNanotimeFunc(), interval)
+func NewThrottledMonotonicClock(
+	interval time.Duration,
+	opts ...ThrottledOption,
+) *ThrottledClock {
+	return NewThrottledClock(DefaultNanotimeFunc(), interval, opts...)
+}
+
+// NewThrottledMonotonicClockMillis creates a new ThrottledClock that uses
+// NewMonotonicNanoFunc as its backing time function and updates every
+// millisecond. See NewThrottledClock for more information.
+func NewThrottledMonotonicClockMillis(opts ...ThrottledOption) *ThrottledClock {
+	return NewThrottledMonotonicClock(time.Millisecond, opts...)
 }
 
 // NewThrottledWallClock creates a new ThrottledClock that uses NewWallNanoFunc
 // as its backing time function. See NewThrottledClock for more information.
-func NewThrottledWallClock(interval time.Duration) *ThrottledClock {
-	return NewThrottledClock(DefaultWallNanotimeFunc(), interval)
+func NewThrottledWallClock(
+	interval time.Duration,
+	opts ...ThrottledOption,
+) *ThrottledClock {
+	return NewThrottledClock(DefaultWallNanotimeFunc(), interval, opts...)
+}
+
+// NewThrottledWallClockMillis creates a new ThrottledClock that uses
+// NewWallNanoFunc as its backing time function and updates every millisecond.
+// See NewThrottledClock for more information.
+func NewThrottledWallClockMillis(opts ...ThrottledOption) *ThrottledClock {
+	return NewThrottledWallClock(time.Millisecond, opts...)
 }
 
 // After returns a channel that receives the current time after d has elapsed.
@@ -100,27 +198,61 @@ func (c *ThrottledClock) After(d time.Duration) <-chan time.Time {
 // elapsed. The timer may be stopped and reset. This method is not throttled
 // and uses Go's runtime timers.
 func (c *ThrottledClock) AfterFunc(d time.Duration, fn func()) *Timer {
-	x := time.AfterFunc(d, fn)
-	return &Timer{
-		C:     x.C,
-		timer: x,
-	}
+	return newRuntimeTimer(d, fn)
+}
+
+// AfterFuncContext is like [ThrottledClock.AfterFunc], but fn is not called if
+// ctx is done before d has elapsed. This method is not throttled and uses Go's
+// runtime timers.
+func (c *ThrottledClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+// DeadlineContext returns a copy of parent that is canceled once the clock's
+// source time reaches ns. This method is not throttled and uses Go's runtime
+// timers.
+func (c *ThrottledClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return runtimeDeadlineContext(parent, time.Duration(ns-c.source()))
+}
+
+// AfterFuncClock is like [ThrottledClock.AfterFunc], but d is measured using
+// the clock's memoized time rather than Go's runtime timers: fn is called in
+// its own goroutine once the clock's internal time has advanced by at least d,
+// as observed at the clock's update interval. The returned timer may be
+// stopped and reset, relative to the clock's internal time. Timers created by
+// AfterFuncClock do not fire once the clock has been stopped.
+func (c *ThrottledClock) AfterFuncClock(d time.Duration, fn func()) *Timer {
+	return c.scheduler().AfterFunc(d, fn)
 }
 
 // Interval returns the interval at which the clock updates its internal time.
+// The interval of a clock created by NewAdaptiveThrottledClock changes over
+// time.
 func (c *ThrottledClock) Interval() time.Duration {
-	return c.interval
+	return c.interval.Load()
 }
 
 // Nanotime returns the current time as integer nanoseconds.
 func (c *ThrottledClock) Nanotime() int64 {
-	return c.now.Load()
+	return c.load()
 }
 
 // NewStopwatch returns a new Stopwatch that uses the current clock for
-// measuring time. The clock's current time is used as the stopwatch's epoch.
-func (c *ThrottledClock) NewStopwatch() *Stopwatch {
-	return newStopwatch(c)
+// measuring time, configured by the given options. Unless the stopwatch is
+// created stopped, the clock's current time is used as its epoch.
+func (c *ThrottledClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+// NewAlignedTicker returns a new Ticker that receives time ticks at every
+// multiple of d of the clock's source time, starting with the first multiple
+// after the current time. This method is not throttled and uses Go's runtime
+// timers. If d is not greater than zero, NewAlignedTicker will panic.
+func (c *ThrottledClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return newRuntimeAlignedTicker(d, untilBoundary(c.source(), d))
 }
 
 // NewTicker returns a new Ticker that receives time ticks every d. This method
@@ -134,19 +266,22 @@ func (c *ThrottledClock) NewTicker(d time.Duration) *Ticker {
 	}
 }
 
+// NewTickerFunc returns a new Ticker that calls fn after each tick every d.
+// This method is not throttled and uses Go's runtime timers. If d is not
+// greater than zero, NewTickerFunc will panic.
+func (c *ThrottledClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return newRuntimeTickerFunc(d, fn)
+}
+
 // NewTimer returns a new Timer that receives a time tick after d. This method
 // is not throttled and uses Go's runtime timers.
 func (c *ThrottledClock) NewTimer(d time.Duration) *Timer {
-	x := time.NewTimer(d)
-	return &Timer{
-		C:     x.C,
-		timer: x,
-	}
+	return newRuntimeTimer(d, nil)
 }
 
 // Now returns the current time as time.Time.
 func (c *ThrottledClock) Now() time.Time {
-	return time.Unix(0, c.now.Load())
+	return time.Unix(0, c.load())
 }
 
 // Since returns the amount of time that elapsed between the clock's internal
@@ -167,6 +302,34 @@ func (c *ThrottledClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+// SleepUntil puts the current goroutine to sleep until the clock's source time
+// reaches t. This method is not throttled and uses Go's runtime timers.
+func (c *ThrottledClock) SleepUntil(t time.Time) {
+	c.SleepUntilNanotime(t.UnixNano())
+}
+
+// SleepUntilNanotime is like [ThrottledClock.SleepUntil], but the target time
+// is given as integer nanoseconds. This method is not throttled and uses Go's
+// runtime timers.
+func (c *ThrottledClock) SleepUntilNanotime(ns int64) {
+	time.Sleep(time.Duration(ns - c.source()))
+}
+
+// Snapshot returns the clock's internal time both as a [time.Time] and as
+// integer nanoseconds, derived from a single read of the memoized time.
+func (c *ThrottledClock) Snapshot() (time.Time, int64) {
+	ns := c.load()
+	return time.Unix(0, ns), ns
+}
+
+// Staleness returns how long it has been since the clock last updated its
+// internal time, as measured by the runtime's monotonic time rather than by
+// the clock itself. A staleness well beyond the clock's interval indicates
+// that the goroutine updating the clock is not being scheduled in time.
+func (c *ThrottledClock) Staleness() time.Duration {
+	return time.Duration(chrono.Nanotime() - c.updated.Load())
+}
+
 // Stop stops the clock. Note that this has no effect on currently-running
 // timers.
 func (c *ThrottledClock) Stop() {
@@ -183,6 +346,21 @@ func (c *ThrottledClock) Tick(d time.Duration) <-chan time.Time {
 	return time.Tick(d)
 }
 
+// TickContext is like [ThrottledClock.Tick], but the underlying ticker is
+// stopped and the returned channel is closed once ctx is done.
+func (c *ThrottledClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return tickContext(ctx, c.NewTicker(d))
+}
+
+// TimeoutContext returns a copy of parent that is canceled once d has elapsed.
+// This method is not throttled and uses Go's runtime timers.
+func (c *ThrottledClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
+
 func (c *ThrottledClock) run(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -192,7 +370,131 @@ func (c *ThrottledClock) run(interval time.Duration) {
 		case <-c.done:
 			return
 		case <-ticker.C:
-			c.now.Store(c.nowfn())
+			c.update()
+			if c.target > 0 {
+				if next := c.adapt(interval); next != interval {
+					interval = next
+					ticker.Reset(interval)
+				}
+			}
+		}
+	}
+}
+
+// adapt returns the interval that would have brought the ratio of updates to
+// reads since the previous call to the clock's target, limited to a factor of
+// two of interval and to the adaptive bounds.
+func (c *ThrottledClock) adapt(interval time.Duration) time.Duration {
+	var (
+		reads = c.reads.Load()
+		delta = reads - c.seen
+		next  = 2 * interval
+	)
+	c.seen = reads
+
+	if delta > 0 {
+		ideal := time.Duration(float64(interval) / (c.target * float64(delta)))
+		next = max(interval/2, min(ideal, next))
+	}
+
+	next = max(_adaptiveMinInterval, min(next, _adaptiveMaxInterval))
+	c.interval.Store(next)
+	return next
+}
+
+// Stats returns the number of times that the clock's source time function has
+// been called, as updates, and, if reads are counted, the number of times that
+// the clock's memoized time has been read, e.g. via [ThrottledClock.Nanotime]
+// or [ThrottledClock.Now], as reads. Their ratio indicates how many time calls
+// the clock is saving, which is useful for tuning its interval. Updates
+// include calls made by unthrottled methods, such as
+// [ThrottledClock.DeadlineContext].
+//
+// Reads are not counted by default: counting them adds a contended write to
+// every read, so only clocks created with [WithReadCounting] and adaptive
+// clocks (see NewAdaptiveThrottledClock), which need them, count reads. For
+// any other clock, reads is always 0.
+func (c *ThrottledClock) Stats() (updates int64, reads int64) {
+	return c.updates.Load(), c.reads.Load()
+}
+
+// load counts a read of the clock's memoized time and returns it, or, if the
+// clock has a maximum staleness that the memoized time exceeds, updates and
+// returns it.
+func (c *ThrottledClock) load() int64 {
+	if c.counting {
+		c.reads.Inc()
+	}
+
+	if c.maxStale > 0 && c.Staleness() > c.maxStale && !c.stopped.Load() {
+		// Only one reader updates the clock; the others use the stale time
+		// rather than all calling the source at once.
+		if c.stale.CAS(false, true) {
+			defer c.stale.Store(false)
+			return c.update()
+		}
+	}
+	return c.now.Load()
+}
+
+// scheduler returns the [FakeClock] that schedules the clock's AfterFuncClock
+// timers, creating it on first use so that clocks that never use such timers
+// do not pay to keep it updated.
+func (c *ThrottledClock) scheduler() *FakeClock {
+	c.schedMu.Lock()
+	defer c.schedMu.Unlock()
+
+	sched, ok := c.sched.Load().(*FakeClock)
+	if !ok {
+		sched = NewFakeClock()
+		sched.SetNanotime(c.now.Load())
+		c.sched.Store(sched)
+	}
+	return sched
+}
+
+// source calls the clock's source time function, counting the call.
+func (c *ThrottledClock) source() int64 {
+	c.updates.Inc()
+	return c.nowfn()
+}
+
+// update sets the clock's memoized time from its source and returns it. When
+// reads may also update the clock (see WithMaxStaleness), updates race with
+// one another, so the memoized time only moves forward; otherwise, only the
+// clock's goroutine updates it, and the source's time is used as-is.
+func (c *ThrottledClock) update() int64 {
+	now := c.source()
+	if c.maxStale > 0 {
+		now = storeMax(&c.now, now)
+		storeMax(&c.updated, chrono.Nanotime())
+	} else {
+		c.now.Store(now)
+		c.updated.Store(chrono.Nanotime())
+	}
+
+	if sched, ok := c.sched.Load().(*FakeClock); ok {
+		// Advance the scheduler to the latest time, never backward, since
+		// another update may have stored a later time after this one.
+		c.schedMu.Lock()
+		if latest := c.now.Load(); latest > sched.Nanotime() {
+			sched.SetNanotime(latest)
+		}
+		c.schedMu.Unlock()
+	}
+	return now
+}
+
+// storeMax stores n in x if n is greater than x's value, returning the
+// resulting value of x.
+func storeMax(x *atomic.Int64, n int64) int64 {
+	for {
+		prev := x.Load()
+		if n <= prev {
+			return prev
+		}
+		if x.CAS(prev, n) {
+			return n
 		}
 	}
 }
@@ -21,6 +21,7 @@
 package clock_test
 
 import (
+	"context"
 	"runtime"
 	"sync"
 	"testing"
@@ -46,6 +47,21 @@ func TestThrottledClock_Constructors(t *testing.T) {
 				return clock.NewThrottledWallClock(d)
 			},
 		},
+		"NewThrottledMonotonicClockMillis": {
+			clockFn: func(time.Duration) *clock.ThrottledClock {
+				return clock.NewThrottledMonotonicClockMillis()
+			},
+		},
+		"NewThrottledWallClockMillis": {
+			clockFn: func(time.Duration) *clock.ThrottledClock {
+				return clock.NewThrottledWallClockMillis()
+			},
+		},
+		"NewAdaptiveThrottledClock": {
+			clockFn: func(time.Duration) *clock.ThrottledClock {
+				return clock.NewAdaptiveThrottledClock(clock.DefaultNanotimeFunc(), 0.5)
+			},
+		},
 	}
 
 	for name, tt := range cases {
@@ -108,6 +124,29 @@ func TestThrottledClock_Timers(t *testing.T) {
 		}
 	}()
 
+	// ThrottledClock.NewTickerFunc
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		tickC := make(chan struct{}, 1)
+		ticker := clk.NewTickerFunc(time.Millisecond, func() {
+			select {
+			case tickC <- struct{}{}:
+			default:
+			}
+		})
+		defer ticker.Stop()
+
+		for i := 0; i < 10; i++ {
+			select {
+			case <-tickC:
+			case <-time.After(time.Second):
+				require.FailNow(t, "timer did not fire")
+			}
+		}
+	}()
+
 	// ThrottledClock.Tick
 	wg.Add(1)
 	go func() {
@@ -223,6 +262,174 @@ func TestThrottledClock_Internals(t *testing.T) {
 	require.Equal(t, prev, clk.Nanotime())
 }
 
+func TestThrottledClock_Stats(t *testing.T) {
+	var (
+		calls atomic.Int64
+		nowfn = func() int64 {
+			return calls.Inc()
+		}
+		clk = clock.NewThrottledClock(nowfn, time.Hour, clock.WithReadCounting())
+	)
+	defer clk.Stop()
+
+	// The clock reads its source once when it is created.
+	updates, reads := clk.Stats()
+	require.EqualValues(t, 1, updates)
+	require.Zero(t, reads)
+
+	for i := 0; i < 10; i++ {
+		clk.Nanotime()
+		clk.Now()
+	}
+	clk.Since(time.Unix(0, 0))
+
+	updates, reads = clk.Stats()
+	require.EqualValues(t, 1, updates)
+	require.EqualValues(t, 21, reads)
+
+	// Unthrottled methods read the source directly.
+	_, cancel := clk.DeadlineContext(context.Background(), int64(time.Hour))
+	cancel()
+
+	updates, reads = clk.Stats()
+	require.EqualValues(t, 2, updates)
+	require.EqualValues(t, calls.Load(), updates)
+	require.EqualValues(t, 21, reads)
+
+	// Snapshots read the memoized time once.
+	now, ns := clk.Snapshot()
+	require.Equal(t, ns, now.UnixNano())
+	require.Equal(t, clk.Nanotime(), ns)
+
+	updates, reads = clk.Stats()
+	require.EqualValues(t, 2, updates)
+	require.EqualValues(t, 23, reads)
+}
+
+func TestThrottledClock_Staleness(t *testing.T) {
+	clk := clock.NewThrottledClock(func() int64 { return 0 }, time.Hour)
+	defer clk.Stop()
+
+	require.Less(t, clk.Staleness(), time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	require.GreaterOrEqual(t, clk.Staleness(), 10*time.Millisecond)
+}
+
+func TestThrottledClock_MaxStaleness(t *testing.T) {
+	opts := clock.DefaultThrottledOptions().With(clock.WithMaxStaleness(time.Second))
+	require.Equal(t, time.Second, opts.MaxStaleness)
+
+	var (
+		calls atomic.Int64
+		nowfn = func() int64 {
+			return calls.Inc()
+		}
+		clk = clock.NewThrottledClock(
+			nowfn,
+			time.Hour,
+			clock.WithMaxStaleness(50*time.Millisecond),
+		)
+	)
+	defer clk.Stop()
+
+	// Reads within the maximum staleness use the memoized time.
+	require.EqualValues(t, 1, clk.Nanotime())
+	require.EqualValues(t, 1, calls.Load())
+
+	// Once the memoized time is too stale, reads update it directly.
+	time.Sleep(100 * time.Millisecond)
+	require.EqualValues(t, 2, clk.Nanotime())
+	require.EqualValues(t, 2, clk.Now().UnixNano())
+
+	updates, _ := clk.Stats()
+	require.EqualValues(t, 2, updates)
+}
+
+func TestThrottledClock_MaxStaleness_Monotonic(t *testing.T) {
+	var (
+		calls atomic.Int64
+		nowfn = func() int64 {
+			return calls.Inc()
+		}
+		clk = clock.NewThrottledClock(
+			nowfn,
+			time.Microsecond,
+			clock.WithMaxStaleness(1),
+		)
+		backward atomic.Int64
+		wg       sync.WaitGroup
+	)
+
+	// Readers and the clock's goroutine update the clock concurrently, but its
+	// time never moves backward.
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var prev int64
+			for j := 0; j < 1000; j++ {
+				now := clk.Nanotime()
+				if now < prev {
+					backward.Inc()
+				}
+				prev = now
+			}
+		}()
+	}
+	wg.Wait()
+	require.Zero(t, backward.Load())
+
+	// Once stopped, reads no longer update the clock.
+	clk.Stop()
+	prev := clk.Nanotime()
+	time.Sleep(time.Millisecond)
+	require.Equal(t, prev, clk.Nanotime())
+}
+
+func TestThrottledClock_ReadCounting(t *testing.T) {
+	opts := clock.DefaultThrottledOptions().With(clock.WithReadCounting())
+	require.True(t, opts.CountReads)
+
+	// Reads are not counted by default.
+	clk := clock.NewThrottledClock(func() int64 { return 0 }, time.Hour)
+	defer clk.Stop()
+
+	clk.Nanotime()
+	clk.Now()
+	clk.Snapshot()
+
+	updates, reads := clk.Stats()
+	require.EqualValues(t, 1, updates)
+	require.Zero(t, reads)
+}
+
+func TestAdaptiveThrottledClock(t *testing.T) {
+	clk := clock.NewAdaptiveThrottledClock(clock.DefaultNanotimeFunc(), 0.01)
+	defer clk.Stop()
+	require.Equal(t, time.Millisecond, clk.Interval())
+
+	// Without any reads, the interval grows to its maximum.
+	require.Eventually(t, func() bool {
+		return clk.Interval() == 100*time.Millisecond
+	}, 5*time.Second, time.Millisecond)
+
+	// Frequent reads shrink the interval again.
+	require.Eventually(t, func() bool {
+		for i := 0; i < 100_000; i++ {
+			clk.Nanotime()
+		}
+		return clk.Interval() < 100*time.Millisecond
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestAdaptiveThrottledClock_InvalidTarget(t *testing.T) {
+	for _, target := range []float64{-1, 0, 1.5} {
+		require.Panics(t, func() {
+			clock.NewAdaptiveThrottledClock(clock.DefaultNanotimeFunc(), target)
+		})
+	}
+}
+
 func TestThrottledClock_Stopwatch(t *testing.T) {
 	var (
 		now   = atomic.NewInt64(0)
@@ -252,6 +459,63 @@ func TestThrottledClock_Stopwatch(t *testing.T) {
 	require.Equal(t, time.Second, stopwatch.Elapsed())
 }
 
+func TestThrottledClockFrom(t *testing.T) {
+	var (
+		src = clock.NewFakeClock()
+		clk = clock.NewThrottledClockFrom(src, time.Millisecond)
+	)
+	defer clk.Stop()
+
+	require.Equal(t, src.Nanotime(), clk.Nanotime())
+
+	for i := 0; i < 3; i++ {
+		prev := src.Nanotime()
+		src.Add(time.Second)
+		waitForChange(t, clk, prev)
+		require.Equal(t, src.Nanotime(), clk.Nanotime())
+		require.True(t, clk.Now().Equal(src.Now()))
+	}
+}
+
+func TestThrottledClock_AfterFuncClock(t *testing.T) {
+	var (
+		src    = clock.NewFakeClock()
+		clk    = clock.NewThrottledClockFrom(src, time.Millisecond)
+		called = make(chan struct{}, 1)
+		timer  = clk.AfterFuncClock(time.Second, func() {
+			called <- struct{}{}
+		})
+	)
+	defer clk.Stop()
+
+	// Real time elapsing has no effect on the timer.
+	select {
+	case <-called:
+		require.FailNow(t, "timer fired before clock time elapsed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Advancing the source clock fires the timer once the throttled clock
+	// observes the new time.
+	src.Add(time.Second)
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		require.FailNow(t, "timer did not fire")
+	}
+
+	// Timers can be reset relative to the clock's time, and stopped.
+	require.False(t, timer.Reset(time.Second))
+	require.True(t, timer.Stop())
+	src.Add(time.Hour)
+	waitForChange(t, clk, src.Nanotime()-int64(time.Hour))
+	select {
+	case <-called:
+		require.FailNow(t, "stopped timer fired")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
 func waitForChange(t *testing.T, clk *clock.ThrottledClock, prev int64) {
 	var (
 		done = make(chan struct{})
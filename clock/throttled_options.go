@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import "time"
+
+// ThrottledOptions configure a [ThrottledClock].
+type ThrottledOptions struct {
+	// MaxStaleness configures how stale a [ThrottledClock]'s memoized time may
+	// become before reads fall back to calling its time function directly. See
+	// [WithMaxStaleness] for more information.
+	MaxStaleness time.Duration
+	// CountReads configures whether a [ThrottledClock] counts reads of its
+	// memoized time. See [WithReadCounting] for more information.
+	CountReads bool
+}
+
+// DefaultThrottledOptions returns a new [ThrottledOptions] with sane defaults.
+func DefaultThrottledOptions() ThrottledOptions {
+	return ThrottledOptions{}
+}
+
+// With returns a new [ThrottledOptions] with opts merged on top of o.
+func (o ThrottledOptions) With(opts ...ThrottledOption) ThrottledOptions {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o ThrottledOptions) apply(opts *ThrottledOptions) {
+	if o.MaxStaleness > 0 {
+		opts.MaxStaleness = o.MaxStaleness
+	}
+
+	if o.CountReads {
+		opts.CountReads = true
+	}
+}
+
+// A ThrottledOption configures a [ThrottledClock].
+type ThrottledOption interface {
+	apply(*ThrottledOptions)
+}
+
+type throttledOptionFunc func(*ThrottledOptions)
+
+func (f throttledOptionFunc) apply(o *ThrottledOptions) {
+	f(o)
+}
+
+// WithMaxStaleness returns a [ThrottledOption] that bounds the staleness of a
+// [ThrottledClock]: if its memoized time has not been updated within d (e.g.
+// because the goroutine updating it has been starved), reads such as
+// [ThrottledClock.Nanotime] and [ThrottledClock.Now] call the clock's time
+// function directly instead, updating the memoized time as they do so. Only
+// one read at a time updates the clock; concurrent reads use the stale time
+// meanwhile. Because reads and the clock's goroutine may then update the clock
+// concurrently, the memoized time never moves backward, even if the time
+// function does. Once the clock has been stopped, reads no longer update it.
+//
+// Detecting staleness requires reading the runtime's monotonic time on each
+// read of the clock, so this option is only worthwhile when the clock's time
+// function is more expensive than that. If d is not greater than zero, the
+// clock's staleness is not bounded.
+func WithMaxStaleness(d time.Duration) ThrottledOption {
+	return throttledOptionFunc(func(o *ThrottledOptions) {
+		o.MaxStaleness = d
+	})
+}
+
+// WithReadCounting returns a [ThrottledOption] that configures a
+// [ThrottledClock] to count reads of its memoized time, as reported by
+// [ThrottledClock.Stats], e.g. for tuning its interval. Counting reads adds an
+// atomic write to every read, so it is disabled by default; adaptive clocks
+// always count reads.
+func WithReadCounting() ThrottledOption {
+	return throttledOptionFunc(func(o *ThrottledOptions) {
+		o.CountReads = true
+	})
+}
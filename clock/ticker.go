@@ -21,32 +21,211 @@
 package clock
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"time"
+
+	"go.uber.org/atomic"
 )
 
 // A Ticker is functionally equivalent to a [time.Ticker]. A Ticker must be
 // created by [Clock.NewTicker].
 type Ticker struct {
-	C      <-chan time.Time
-	ticker *time.Ticker
-	fake   *fakeTimer
+	C       <-chan time.Time
+	ticker  *time.Ticker
+	fake    *fakeTimer
+	fn      func()
+	done    chan struct{}
+	pending *time.Timer // aligned tickers only
+	manual  bool
+	missed  atomic.Int64 // manual tickers only
+	stopped atomic.Bool
+	mu      sync.Mutex
+}
+
+func newRuntimeTickerFunc(d time.Duration, fn func()) *Ticker {
+	t := &Ticker{
+		ticker: time.NewTicker(d),
+		fn:     fn,
+	}
+	t.startFunc()
+	return t
+}
+
+// NewManualTicker returns a new [Ticker] that only ticks when the returned
+// function is called, regardless of how much time elapses. Each call sends
+// clk's current time on the ticker's channel, dropping the tick if the channel
+// is full. Once the ticker is stopped, calling the function has no effect
+// until the ticker is reset; the duration given to [Ticker.Reset] must be
+// greater than zero, but is otherwise ignored.
+func NewManualTicker(clk Clock) (*Ticker, func()) {
+	var (
+		ch = make(chan time.Time, 1)
+		t  = &Ticker{
+			C:      ch,
+			manual: true,
+		}
+	)
+
+	return t, func() {
+		if !t.stopped.Load() && !tick(ch, clk.Nanotime()) {
+			t.missed.Inc()
+		}
+	}
+}
+
+// A tickerAfterScheduler is a [Clock] that can schedule a [Ticker] whose first
+// tick is delayed by an arbitrary duration, such as a [FakeClock] or a clock
+// that wraps one. It allows clocks that wrap other clocks to align tickers to
+// their own view of time while leaving scheduling to the wrapped clock.
+type tickerAfterScheduler interface {
+	newTickerAfter(first time.Duration, d time.Duration) *Ticker
+}
+
+// newTickerAfter returns a new [Ticker] that first ticks after first, and then
+// every d thereafter, scheduled by clk if it is a [tickerAfterScheduler] or by
+// the runtime otherwise.
+func newTickerAfter(clk Clock, first time.Duration, d time.Duration) *Ticker {
+	if x, ok := clk.(tickerAfterScheduler); ok {
+		return x.newTickerAfter(first, d)
+	}
+	return newRuntimeAlignedTicker(d, first)
+}
+
+// newRuntimeAlignedTicker returns a new [Ticker] that first ticks after delay,
+// and then every d thereafter.
+func newRuntimeAlignedTicker(d time.Duration, delay time.Duration) *Ticker {
+	var (
+		ch = make(chan time.Time, 1)
+		t  = &Ticker{
+			C:      ch,
+			ticker: time.NewTicker(d),
+			fn: func() {
+				tick(ch, time.Now().UnixNano())
+			},
+		}
+	)
+
+	// The underlying ticker is started once the first boundary is reached.
+	// The lock is held so that the boundary cannot be handled before pending
+	// has been assigned.
+	t.ticker.Stop()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = time.AfterFunc(delay, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// The ticker was stopped or reset before the boundary was reached.
+		if t.pending == nil {
+			return
+		}
+
+		t.pending = nil
+		t.ticker.Reset(d)
+		t.startFuncNosync()
+		t.fn()
+	})
+
+	return t
+}
+
+// untilBoundary returns the duration from now until the next multiple of d.
+func untilBoundary(now int64, d time.Duration) time.Duration {
+	if d <= 0 {
+		panic(errors.New("non-positive interval for NewAlignedTicker"))
+	}
+
+	offset := now % int64(d)
+	if offset < 0 {
+		offset += int64(d)
+	}
+
+	return d - time.Duration(offset)
+}
+
+// tickContext forwards ticks from ticker until ctx is done, at which point
+// ticker is stopped and the returned channel is closed. Like a [time.Ticker],
+// ticks are dropped if the receiver is not ready.
+func tickContext(ctx context.Context, ticker *Ticker) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+
+	go func() {
+		defer close(ch)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				select {
+				case ch <- now:
+				default:
+				}
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Chan returns the channel on which the ticker delivers ticks. It is
+// equivalent to t.C.
+func (t *Ticker) Chan() <-chan time.Time {
+	return t.C
+}
+
+// Drain discards any ticks that are buffered in the ticker's channel without
+// blocking, returning the number of ticks discarded. This is useful for
+// resynchronizing with a ticker after advancing a [FakeClock] by several
+// periods at once.
+func (t *Ticker) Drain() int {
+	return drain(t.C)
+}
+
+// Missed returns the number of ticks that the ticker has dropped because its
+// channel was full, including ticks for periods that elapsed entirely while a
+// [FakeClock] was advanced by several periods at once. Missed is only tracked
+// for tickers created by a [FakeClock] or by [NewManualTicker]; tickers backed
+// by the runtime drop ticks without reporting them, so Missed always returns 0
+// for them. Tickers that run callbacks have no channel and never miss ticks.
+func (t *Ticker) Missed() int64 {
+	if t.fake != nil {
+		return t.fake.missed.Load()
+	}
+	return t.missed.Load()
 }
 
 // Reset stops a ticker and resets its period to the specified duration. The
 // next tick will arrive after the new period elapses. The duration d must be
 // greater than zero; if not, Reset will panic.
 func (t *Ticker) Reset(d time.Duration) {
+	t.ResetActive(d)
+}
+
+// ResetActive is like [Ticker.Reset], but reports whether the ticker was active
+// prior to being reset, i.e. whether it had not been stopped. For tickers
+// created by a [FakeClock], this reflects whether the ticker was scheduled on
+// the clock.
+func (t *Ticker) ResetActive(d time.Duration) bool {
 	if t.ticker != nil {
 		t.ticker.Reset(d)
-		return
+		t.startFunc()
+		return !t.stopped.Swap(false)
 	}
 
 	if d <= 0 {
 		panic(errors.New("non-positive interval for Ticker.Reset"))
 	}
 
-	t.fake.resetTimer(d)
+	if t.manual {
+		return !t.stopped.Swap(false)
+	}
+
+	return t.fake.resetTimer(d)
 }
 
 // Stop turns off a ticker. After Stop, no more ticks will be sent. Stop does
@@ -54,9 +233,73 @@ func (t *Ticker) Reset(d time.Duration) {
 // channel from seeing an erroneous "tick".
 func (t *Ticker) Stop() {
 	if t.ticker != nil {
+		t.stopFunc()
 		t.ticker.Stop()
+		t.stopped.Store(true)
+		return
+	}
+
+	if t.manual {
+		t.stopped.Store(true)
 		return
 	}
 
 	t.fake.removeTimer()
 }
+
+func (t *Ticker) startFunc() {
+	if t.fn == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancelPendingNosync()
+	t.startFuncNosync()
+}
+
+func (t *Ticker) startFuncNosync() {
+	if t.done != nil {
+		return
+	}
+
+	var (
+		tick = t.ticker.C
+		done = make(chan struct{})
+	)
+
+	t.done = done
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-tick:
+				t.fn()
+			}
+		}
+	}()
+}
+
+func (t *Ticker) stopFunc() {
+	if t.fn == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancelPendingNosync()
+	if t.done != nil {
+		close(t.done)
+		t.done = nil
+	}
+}
+
+func (t *Ticker) cancelPendingNosync() {
+	if t.pending != nil {
+		t.pending.Stop()
+		t.pending = nil
+	}
+}
@@ -21,15 +21,70 @@
 package clock
 
 import (
+	"errors"
+	"sync"
 	"time"
+
+	"go.mway.dev/chrono"
+	"go.uber.org/atomic"
 )
 
 // A Timer is functionally equivalent to a [time.Timer]. A Timer must be
 // created by [Clock.NewTimer].
 type Timer struct {
 	C     <-chan time.Time
-	timer *time.Timer
+	timer *time.Timer // guarded by mu, since ResetFunc replaces it
 	fake  *fakeTimer
+	// deadline is the monotonic time at which a runtime timer fires, or 0 if
+	// the timer has been stopped.
+	deadline atomic.Int64
+	mu       sync.Mutex
+}
+
+// newRuntimeTimer returns a new [Timer] backed by a [time.Timer] that fires
+// after d. If fn is non-nil, it is called in its own goroutine when the timer
+// fires; otherwise, the timer's channel receives the current time.
+func newRuntimeTimer(d time.Duration, fn func()) *Timer {
+	var (
+		t   = &Timer{}
+		now = chrono.Nanotime()
+	)
+
+	if fn != nil {
+		t.timer = time.AfterFunc(d, fn)
+	} else {
+		t.timer = time.NewTimer(d)
+		t.C = t.timer.C
+	}
+
+	t.deadline.Store(now + int64(d))
+	return t
+}
+
+// Active reports whether the timer is pending, i.e. it has neither fired nor
+// been stopped. Runtime timers are considered to have fired once their
+// deadline has passed, even if the runtime has not yet delivered the tick.
+func (t *Timer) Active() bool {
+	if t.fake == nil {
+		deadline := t.deadline.Load()
+		return deadline != 0 && chrono.Nanotime() < deadline
+	}
+	return t.fake.active()
+}
+
+// Chan returns the channel on which the timer delivers its tick. It is
+// equivalent to t.C.
+func (t *Timer) Chan() <-chan time.Time {
+	return t.C
+}
+
+// Drain discards any ticks that are buffered in the timer's channel without
+// blocking, returning the number of ticks discarded. This is useful for
+// resynchronizing with a timer after it has been reset or after a [FakeClock]
+// has been advanced. Timers created by [Clock.AfterFunc] have no channel, so
+// Drain always returns 0 for them.
+func (t *Timer) Drain() int {
+	return drain(t.C)
 }
 
 // Reset changes the timer to expire after duration d. It returns true if the
@@ -37,22 +92,85 @@ type Timer struct {
 //
 // See Reset documentation on [time.Timer] for more information.
 func (t *Timer) Reset(d time.Duration) bool {
-	if t.timer != nil {
+	if t.fake == nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.deadline.Store(chrono.Nanotime() + int64(d))
 		return t.timer.Reset(d)
 	}
 
 	return t.fake.resetTimer(d)
 }
 
+// ResetFunc is like [Timer.Reset], but also replaces the function that the
+// timer calls when it fires with fn, e.g. when a retry state machine changes
+// its next action. It returns true if the timer had been active, false if the
+// timer had expired or been stopped. Timers created by NewTimer stop delivering
+// ticks on their channel once ResetFunc is called, and call fn instead.
+//
+// For timers backed by Go's runtime timers, the underlying timer is stopped and
+// replaced by a new one, so a call to the previous function that has already
+// started is not affected. If fn is nil, ResetFunc will panic.
+func (t *Timer) ResetFunc(d time.Duration, fn func()) bool {
+	if fn == nil {
+		panic(errors.New("nil func passed to Timer.ResetFunc"))
+	}
+
+	if t.fake == nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		active := t.timer.Stop()
+		t.deadline.Store(chrono.Nanotime() + int64(d))
+		t.timer = time.AfterFunc(d, fn)
+		return active
+	}
+
+	return t.fake.resetTimerFunc(d, fn)
+}
+
 // Stop prevents the Timer from firing. It returns true if the call stops the
 // timer, false if the timer has already expired or been stopped. Stop does not
 // close the channel, to prevent a read from the channel succeeding
-// incorrectly.
+// incorrectly. If Stop returns false because the timer already fired, the
+// channel may still contain that tick; use [Timer.StopAndDrain] to discard it.
 //
 // See Stop documentation on [time.Timer] for more information.
 func (t *Timer) Stop() bool {
-	if t.timer != nil {
+	if t.fake == nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.deadline.Store(0)
 		return t.timer.Stop()
 	}
 	return t.fake.removeTimer()
 }
+
+// StopAndDrain is like [Timer.Stop], but also discards any tick that the timer
+// has already delivered to its channel, so that a subsequent read from the
+// channel never observes a stale tick. For timers created by a [FakeClock],
+// stopping and draining happen atomically with respect to the clock: the
+// timer cannot fire in between.
+func (t *Timer) StopAndDrain() bool {
+	if t.fake == nil {
+		stopped := t.Stop()
+		drain(t.C)
+		return stopped
+	}
+	return t.fake.stopAndDrain()
+}
+
+// drain non-blockingly receives from ch until it is empty, returning the
+// number of values received.
+func drain(ch <-chan time.Time) (n int) {
+	for {
+		select {
+		case <-ch:
+			n++
+		default:
+			return n
+		}
+	}
+}
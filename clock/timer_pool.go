@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// A TimerPool reuses [Timer]s to avoid allocating a new timer for each of many
+// short-lived waits, e.g. in retry or backoff loops. Timers are obtained with
+// [TimerPool.Get] and returned with [TimerPool.Put] once they are no longer
+// needed. A TimerPool is safe for concurrent use.
+//
+// A timer must not be used after it has been returned to the pool. To avoid
+// spurious ticks, Put stops and drains each timer before it is pooled, so that
+// a timer obtained from Get never delivers a tick from a previous use: a
+// timer's channel must be empty before the timer is reset, as with
+// [time.Timer.Reset].
+type TimerPool struct {
+	clock Clock
+	pool  sync.Pool
+}
+
+// NewTimerPool returns a new [TimerPool] whose timers are created by clk.
+func NewTimerPool(clk Clock) *TimerPool {
+	return &TimerPool{
+		clock: clk,
+	}
+}
+
+// Get returns a timer that fires after d, reusing a pooled timer if one is
+// available.
+func (p *TimerPool) Get(d time.Duration) *Timer {
+	if t, ok := p.pool.Get().(*Timer); ok {
+		t.Reset(d)
+		return t
+	}
+	return p.clock.NewTimer(d)
+}
+
+// Put stops t, discards any tick buffered in its channel, and returns it to
+// the pool. t must have been obtained from [TimerPool.Get], and must not be
+// used by the caller afterward. If t is nil, Put has no effect.
+func (p *TimerPool) Put(t *Timer) {
+	if t == nil {
+		return
+	}
+
+	t.Stop()
+	t.Drain()
+	p.pool.Put(t)
+}
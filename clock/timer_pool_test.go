@@ -0,0 +1,73 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestTimerPool(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		pool  = clock.NewTimerPool(clk)
+		timer = pool.Get(time.Second)
+	)
+
+	clk.Add(time.Second)
+	requireTick(t, timer.C)
+
+	// A timer that fired without being received does not deliver its stale
+	// tick after being reused.
+	timer = pool.Get(time.Second)
+	clk.Add(time.Second)
+	pool.Put(timer)
+	timer = pool.Get(time.Second)
+	requireNoTick(t, timer.C)
+	clk.Add(999 * time.Millisecond)
+	requireNoTick(t, timer.C)
+	clk.Add(time.Millisecond)
+	requireTimeIs(t, int64(3*time.Second), requireTick(t, timer.C))
+
+	// A timer that is returned before firing never fires.
+	pool.Put(timer)
+	timer = pool.Get(time.Hour)
+	require.True(t, timer.Active())
+	pool.Put(timer)
+	require.False(t, timer.Active())
+	clk.Add(time.Hour)
+	requireNoTick(t, timer.C)
+
+	pool.Put(nil)
+}
+
+func TestTimerPool_Runtime(t *testing.T) {
+	pool := clock.NewTimerPool(clock.NewMonotonicClock())
+
+	for i := 0; i < 10; i++ {
+		timer := pool.Get(time.Millisecond)
+		requireTick(t, timer.C)
+		pool.Put(timer)
+	}
+}
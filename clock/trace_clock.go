@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"sync"
+)
+
+var _ Clock = (*TraceClock)(nil)
+
+// A TraceEvent is a single point in time within a recorded trace.
+type TraceEvent struct {
+	// Nanotime is the time at which the event occurred, as integer
+	// nanoseconds.
+	Nanotime int64
+}
+
+// A TraceClock is a [FakeClock] whose time is driven by a recorded trace of
+// events rather than by manual adjustment. The clock starts at the time of the
+// first event, and each call to [TraceClock.Advance] moves the clock to the
+// time of the next event, firing any timers and tickers that are due along the
+// way. This allows a captured timeline to be stepped through deterministically.
+//
+// Because a TraceClock is a FakeClock, its time may still be adjusted manually
+// via [FakeClock.Add] and related functions; doing so does not affect the
+// position of the clock within its trace.
+type TraceClock struct {
+	*FakeClock
+
+	events []TraceEvent
+	next   int
+	mu     sync.Mutex
+}
+
+// NewTraceClock creates a new [TraceClock] that replays the given events in
+// order. If events is empty, the clock starts at zero and cannot be advanced.
+func NewTraceClock(events []TraceEvent) *TraceClock {
+	c := &TraceClock{
+		FakeClock: NewFakeClock(),
+		events:    append([]TraceEvent(nil), events...),
+	}
+
+	if len(c.events) > 0 {
+		c.SetNanotime(c.events[0].Nanotime)
+		c.next = 1
+	}
+
+	return c
+}
+
+// A ReplayClock is a [TraceClock] that replays a predetermined schedule of
+// recorded times. See [NewReplayClock].
+type ReplayClock = TraceClock
+
+// NewReplayClock creates a new [ReplayClock] that replays the given recorded
+// times, as integer nanoseconds, in order. It is shorthand for calling
+// [NewTraceClock] with one [TraceEvent] per time.
+func NewReplayClock(times []int64) *ReplayClock {
+	events := make([]TraceEvent, len(times))
+	for i, ns := range times {
+		events[i].Nanotime = ns
+	}
+	return NewTraceClock(events)
+}
+
+// Advance moves the clock to the time of the next event in its trace, firing
+// any timers that are due. It returns the event that the clock moved to and
+// true, or false if the trace has been exhausted.
+func (c *TraceClock) Advance() (TraceEvent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next >= len(c.events) {
+		return TraceEvent{}, false
+	}
+
+	ev := c.events[c.next]
+	c.next++
+	c.SetNanotime(ev.Nanotime)
+
+	return ev, true
+}
+
+// Remaining returns the number of events in the trace that have not yet been
+// advanced to.
+func (c *TraceClock) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.events) - c.next
+}
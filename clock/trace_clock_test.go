@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestTraceClock(t *testing.T) {
+	var (
+		events = []clock.TraceEvent{
+			{Nanotime: int64(10 * time.Second)},
+			{Nanotime: int64(11 * time.Second)},
+			{Nanotime: int64(13 * time.Second)},
+			{Nanotime: int64(16 * time.Second)},
+		}
+		clk    = clock.NewTraceClock(events)
+		timer1 = clk.NewTimer(time.Second)
+		timer2 = clk.NewTimer(3 * time.Second)
+		timer3 = clk.NewTimer(5 * time.Second)
+	)
+
+	requireClockIs(t, events[0].Nanotime, clk.FakeClock)
+	require.Equal(t, len(events)-1, clk.Remaining())
+
+	// Each timer should fire at the recorded instant that first crosses its
+	// deadline, and in order.
+	for i, timer := range []*clock.Timer{timer1, timer2, timer3} {
+		requireNoTick(t, timer.C)
+
+		ev, ok := clk.Advance()
+		require.True(t, ok)
+		require.Equal(t, events[i+1], ev)
+		requireClockIs(t, ev.Nanotime, clk.FakeClock)
+
+		ts := requireTick(t, timer.C)
+		requireTimeIs(t, ev.Nanotime, ts)
+	}
+
+	require.Zero(t, clk.Remaining())
+
+	_, ok := clk.Advance()
+	require.False(t, ok)
+	requireClockIs(t, events[len(events)-1].Nanotime, clk.FakeClock)
+}
+
+func TestTraceClock_Empty(t *testing.T) {
+	clk := clock.NewTraceClock(nil)
+	requireClockIs(t, 0, clk.FakeClock)
+
+	_, ok := clk.Advance()
+	require.False(t, ok)
+	require.Zero(t, clk.Remaining())
+}
+
+func TestNewReplayClock(t *testing.T) {
+	var (
+		times = []int64{100, 150, 250, 350, 500}
+		clk   = clock.NewReplayClock(times)
+		order = make(chan int, 3)
+	)
+
+	// Schedule timers out of order; they fire in order of their deadlines as
+	// the replay crosses them.
+	for _, i := range []int{2, 0, 1} {
+		i := i
+		clk.AfterFunc(time.Duration(100*(i+1)), func() {
+			order <- i
+		})
+	}
+
+	requireClockIs(t, times[0], clk.FakeClock)
+	for _, want := range []int{0, 1, 2} {
+		for {
+			ev, ok := clk.Advance()
+			require.True(t, ok)
+			if ev.Nanotime >= times[0]+int64(100*(want+1)) {
+				break
+			}
+		}
+
+		select {
+		case have := <-order:
+			require.Equal(t, want, have)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for timer")
+		}
+	}
+
+	require.Zero(t, clk.Remaining())
+}
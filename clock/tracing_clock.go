@@ -0,0 +1,230 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ Clock = (*TracingClock)(nil)
+
+// A TraceEntry records a single operation made on a [TracingClock].
+type TraceEntry struct {
+	// Op is the name of the [Clock] method that was called, e.g. "NewTimer".
+	Op string
+	// Args are the arguments that were given to the method, excluding
+	// contexts and funcs.
+	Args []any
+	// Nanotime is the wrapped clock's time when the operation was made, or,
+	// for Nanotime, Now, and Snapshot, the time that was returned.
+	Nanotime int64
+}
+
+// TraceEvent returns the point in time at which e was recorded as a
+// [TraceEvent], so that a trace can be replayed by a [TraceClock].
+func (e TraceEntry) TraceEvent() TraceEvent {
+	return TraceEvent{
+		Nanotime: e.Nanotime,
+	}
+}
+
+// A TracingClock is a [Clock] that delegates to another clock and records an
+// ordered trace of every operation made on it, e.g. for post-mortem analysis of
+// flaky time-dependent tests. Each operation is recorded as a [TraceEntry],
+// which can be converted to a [TraceEvent] to replay the trace with a
+// [TraceClock]. A TracingClock is safe for concurrent use.
+type TracingClock struct {
+	base    Clock
+	entries []TraceEntry
+	mu      sync.Mutex
+}
+
+// NewTracingClock returns a new [TracingClock] that wraps base, along with a
+// function that returns a copy of the operations recorded so far. Operations
+// made by stopwatches created by the clock are recorded as calls to Nanotime.
+// Recording an operation reads base's time, which is not itself recorded.
+func NewTracingClock(base Clock) (*TracingClock, func() []TraceEntry) {
+	c := &TracingClock{
+		base: base,
+	}
+	return c, c.trace
+}
+
+// After records the call and delegates to the wrapped clock.
+func (c *TracingClock) After(d time.Duration) <-chan time.Time {
+	c.record("After", d)
+	return c.base.After(d)
+}
+
+// AfterFunc records the call and delegates to the wrapped clock.
+func (c *TracingClock) AfterFunc(d time.Duration, fn func()) *Timer {
+	c.record("AfterFunc", d)
+	return c.base.AfterFunc(d, fn)
+}
+
+// AfterFuncContext records the call and delegates to the wrapped clock.
+func (c *TracingClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	c.record("AfterFuncContext", d)
+	c.base.AfterFuncContext(ctx, d, fn)
+}
+
+// DeadlineContext records the call and delegates to the wrapped clock.
+func (c *TracingClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	c.record("DeadlineContext", ns)
+	return c.base.DeadlineContext(parent, ns)
+}
+
+// Nanotime records the call and delegates to the wrapped clock.
+func (c *TracingClock) Nanotime() int64 {
+	ns := c.base.Nanotime()
+	c.append("Nanotime", ns)
+	return ns
+}
+
+// NewAlignedTicker records the call and delegates to the wrapped clock.
+func (c *TracingClock) NewAlignedTicker(d time.Duration) *Ticker {
+	c.record("NewAlignedTicker", d)
+	return c.base.NewAlignedTicker(d)
+}
+
+func (c *TracingClock) newTickerAfter(first time.Duration, d time.Duration) *Ticker {
+	return newTickerAfter(c.base, first, d)
+}
+
+// NewStopwatch records the call and returns a new [Stopwatch] that uses c for
+// measuring time.
+func (c *TracingClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	c.record("NewStopwatch")
+	return newStopwatch(c, opts...)
+}
+
+// NewTicker records the call and delegates to the wrapped clock.
+func (c *TracingClock) NewTicker(d time.Duration) *Ticker {
+	c.record("NewTicker", d)
+	return c.base.NewTicker(d)
+}
+
+// NewTickerFunc records the call and delegates to the wrapped clock.
+func (c *TracingClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	c.record("NewTickerFunc", d)
+	return c.base.NewTickerFunc(d, fn)
+}
+
+// NewTimer records the call and delegates to the wrapped clock.
+func (c *TracingClock) NewTimer(d time.Duration) *Timer {
+	c.record("NewTimer", d)
+	return c.base.NewTimer(d)
+}
+
+// Now records the call and delegates to the wrapped clock.
+func (c *TracingClock) Now() time.Time {
+	now := c.base.Now()
+	c.append("Now", now.UnixNano())
+	return now
+}
+
+// Since records the call and delegates to the wrapped clock.
+func (c *TracingClock) Since(t time.Time) time.Duration {
+	c.record("Since", t)
+	return c.base.Since(t)
+}
+
+// SinceNanotime records the call and delegates to the wrapped clock.
+func (c *TracingClock) SinceNanotime(ns int64) time.Duration {
+	c.record("SinceNanotime", ns)
+	return c.base.SinceNanotime(ns)
+}
+
+// Sleep records the call and delegates to the wrapped clock. The call is
+// recorded before sleeping.
+func (c *TracingClock) Sleep(d time.Duration) {
+	c.record("Sleep", d)
+	c.base.Sleep(d)
+}
+
+// SleepUntil records the call and delegates to the wrapped clock. The call is
+// recorded before sleeping.
+func (c *TracingClock) SleepUntil(t time.Time) {
+	c.record("SleepUntil", t)
+	c.base.SleepUntil(t)
+}
+
+// SleepUntilNanotime records the call and delegates to the wrapped clock. The
+// call is recorded before sleeping.
+func (c *TracingClock) SleepUntilNanotime(ns int64) {
+	c.record("SleepUntilNanotime", ns)
+	c.base.SleepUntilNanotime(ns)
+}
+
+// Snapshot records the call and delegates to the wrapped clock.
+func (c *TracingClock) Snapshot() (time.Time, int64) {
+	now, ns := c.base.Snapshot()
+	c.append("Snapshot", ns)
+	return now, ns
+}
+
+// Tick records the call and delegates to the wrapped clock.
+func (c *TracingClock) Tick(d time.Duration) <-chan time.Time {
+	c.record("Tick", d)
+	return c.base.Tick(d)
+}
+
+// TickContext records the call and delegates to the wrapped clock.
+func (c *TracingClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	c.record("TickContext", d)
+	return c.base.TickContext(ctx, d)
+}
+
+// TimeoutContext records the call and delegates to the wrapped clock.
+func (c *TracingClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	c.record("TimeoutContext", d)
+	return c.base.TimeoutContext(parent, d)
+}
+
+func (c *TracingClock) record(op string, args ...any) {
+	c.append(op, c.base.Nanotime(), args...)
+}
+
+func (c *TracingClock) append(op string, ns int64, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, TraceEntry{
+		Op:       op,
+		Args:     args,
+		Nanotime: ns,
+	})
+}
+
+func (c *TracingClock) trace() []TraceEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]TraceEntry(nil), c.entries...)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+)
+
+func TestTracingClock(t *testing.T) {
+	var (
+		base       = clock.NewFakeClock()
+		clk, trace = clock.NewTracingClock(base)
+	)
+
+	require.Empty(t, trace())
+
+	base.SetNanotime(100)
+	require.EqualValues(t, 100, clk.Nanotime())
+	timer := clk.NewTimer(time.Second)
+	base.Add(time.Second)
+	requireTick(t, timer.C)
+
+	_, cancel := clk.TimeoutContext(context.Background(), time.Minute)
+	cancel()
+	clk.SinceNanotime(0)
+	requireTimeIs(t, 100+int64(time.Second), clk.Now())
+
+	stopwatch := clk.NewStopwatch()
+	stopwatch.Elapsed()
+
+	entries := trace()
+	require.Equal(
+		t,
+		[]clock.TraceEntry{
+			{Op: "Nanotime", Nanotime: 100},
+			{Op: "NewTimer", Args: []any{time.Second}, Nanotime: 100},
+			{
+				Op:       "TimeoutContext",
+				Args:     []any{time.Minute},
+				Nanotime: 100 + int64(time.Second),
+			},
+			{
+				Op:       "SinceNanotime",
+				Args:     []any{int64(0)},
+				Nanotime: 100 + int64(time.Second),
+			},
+			{Op: "Now", Nanotime: 100 + int64(time.Second)},
+			{Op: "NewStopwatch", Nanotime: 100 + int64(time.Second)},
+			{Op: "Nanotime", Nanotime: 100 + int64(time.Second)},
+			{Op: "Nanotime", Nanotime: 100 + int64(time.Second)},
+		},
+		entries,
+	)
+
+	// The returned trace is a copy.
+	entries[0].Op = "changed"
+	require.Equal(t, "Nanotime", trace()[0].Op)
+
+	// Snapshots are recorded with the time that was returned.
+	_, ns := clk.Snapshot()
+	entries = trace()
+	require.Equal(
+		t,
+		clock.TraceEntry{Op: "Snapshot", Nanotime: ns},
+		entries[len(entries)-1],
+	)
+
+	// Traces can be replayed.
+	entries = trace()
+	events := make([]clock.TraceEvent, len(entries))
+	for i, entry := range entries {
+		events[i] = entry.TraceEvent()
+	}
+
+	replay := clock.NewTraceClock(events)
+	require.EqualValues(t, 100, replay.Nanotime())
+	for _, entry := range entries[1:] {
+		got, ok := replay.Advance()
+		require.True(t, ok)
+		require.Equal(t, entry.TraceEvent(), got)
+		require.Equal(t, entry.Nanotime, replay.Nanotime())
+	}
+	_, ok := replay.Advance()
+	require.False(t, ok)
+}
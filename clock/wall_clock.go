@@ -21,6 +21,7 @@
 package clock
 
 import (
+	"context"
 	"time"
 )
 
@@ -41,19 +42,30 @@ func (c *wallClock) After(d time.Duration) <-chan time.Time {
 }
 
 func (c *wallClock) AfterFunc(d time.Duration, fn func()) *Timer {
-	x := time.AfterFunc(d, fn)
-	return &Timer{
-		C:     x.C,
-		timer: x,
-	}
+	return newRuntimeTimer(d, fn)
+}
+
+func (c *wallClock) AfterFuncContext(ctx context.Context, d time.Duration, fn func()) {
+	afterFuncContext(ctx, d, fn, c.AfterFunc)
+}
+
+func (c *wallClock) DeadlineContext(
+	parent context.Context,
+	ns int64,
+) (context.Context, context.CancelFunc) {
+	return runtimeDeadlineContext(parent, time.Duration(ns-c.Nanotime()))
 }
 
 func (c *wallClock) Nanotime() int64 {
 	return c.fn().UnixNano()
 }
 
-func (c *wallClock) NewStopwatch() *Stopwatch {
-	return newStopwatch(c)
+func (c *wallClock) NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	return newStopwatch(c, opts...)
+}
+
+func (c *wallClock) NewAlignedTicker(d time.Duration) *Ticker {
+	return newRuntimeAlignedTicker(d, untilBoundary(c.Nanotime(), d))
 }
 
 func (c *wallClock) NewTicker(d time.Duration) *Ticker {
@@ -64,12 +76,12 @@ func (c *wallClock) NewTicker(d time.Duration) *Ticker {
 	}
 }
 
+func (c *wallClock) NewTickerFunc(d time.Duration, fn func()) *Ticker {
+	return newRuntimeTickerFunc(d, fn)
+}
+
 func (c *wallClock) NewTimer(d time.Duration) *Timer {
-	x := time.NewTimer(d)
-	return &Timer{
-		C:     x.C,
-		timer: x,
-	}
+	return newRuntimeTimer(d, nil)
 }
 
 func (c *wallClock) Now() time.Time {
@@ -88,7 +100,31 @@ func (c *wallClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+func (c *wallClock) SleepUntil(t time.Time) {
+	sleepUntil(c, t.UnixNano())
+}
+
+func (c *wallClock) SleepUntilNanotime(ns int64) {
+	sleepUntil(c, ns)
+}
+
+func (c *wallClock) Snapshot() (time.Time, int64) {
+	now := c.fn()
+	return now, now.UnixNano()
+}
+
 func (c *wallClock) Tick(d time.Duration) <-chan time.Time {
 	//nolint:staticcheck
 	return time.Tick(d)
 }
+
+func (c *wallClock) TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	return tickContext(ctx, c.NewTicker(d))
+}
+
+func (c *wallClock) TimeoutContext(
+	parent context.Context,
+	d time.Duration,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package chrono
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	_epoch     time.Time
+	_epochOnce sync.Once
+)
+
+// MonotonicEpoch returns the approximate wall time at which [Nanotime] was
+// zero. The offset between the monotonic and wall clocks is captured once, the
+// first time that MonotonicEpoch or [NanotimeToWall] is called; the result
+// does not reflect any later adjustments to the wall clock.
+func MonotonicEpoch() time.Time {
+	_epochOnce.Do(func() {
+		var (
+			now = time.Now()
+			ns  = Nanotime()
+		)
+		_epoch = now.Add(-time.Duration(ns)).Round(0)
+	})
+	return _epoch
+}
+
+// NanotimeToWall converts ns, a monotonic time as returned by [Nanotime], to
+// an approximate wall time. See [MonotonicEpoch] for more information.
+func NanotimeToWall(ns int64) time.Time {
+	return MonotonicEpoch().Add(time.Duration(ns))
+}
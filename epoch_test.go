@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package chrono_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono"
+)
+
+func TestMonotonicEpoch(t *testing.T) {
+	epoch := chrono.MonotonicEpoch()
+	require.Equal(t, epoch, chrono.MonotonicEpoch())
+	require.True(t, epoch.Before(time.Now()))
+}
+
+func TestNanotimeToWall(t *testing.T) {
+	var (
+		before = time.Now()
+		wall   = chrono.NanotimeToWall(chrono.Nanotime())
+		after  = time.Now()
+	)
+
+	// Allow for a small amount of skew between the two clocks.
+	require.WithinRange(
+		t,
+		wall,
+		before.Add(-time.Millisecond),
+		after.Add(time.Millisecond),
+	)
+	require.Equal(t, chrono.MonotonicEpoch(), chrono.NanotimeToWall(0))
+}
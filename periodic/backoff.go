@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package periodic
+
+import (
+	"time"
+
+	"go.mway.dev/chrono"
+)
+
+// A BackoffConfig configures how a [Handle]'s period changes in response to
+// the results of its [ErrFunc]. See [WithBackoff] for more information.
+type BackoffConfig struct {
+	// Initial is the period used after a successful invocation. Backoff is
+	// disabled if Initial is not greater than zero.
+	Initial time.Duration
+	// Max caps the period. If Max is not greater than zero, the period is
+	// not capped.
+	Max time.Duration
+	// Factor is multiplied with the current period after each failed
+	// invocation. If Factor is not greater than 1, a factor of 2 is used.
+	Factor float64
+}
+
+func (c BackoffConfig) enabled() bool {
+	return c.Initial > 0
+}
+
+// next returns the period that should follow cur given the result of an
+// invocation, and whether it differs from cur.
+func (c BackoffConfig) next(cur time.Duration, err error) (time.Duration, bool) {
+	if !c.enabled() {
+		return cur, false
+	}
+
+	// A failed invocation grows the current period; a successful one resets
+	// it to the (capped) initial period.
+	var prev time.Duration
+	if err != nil {
+		prev = cur
+	}
+
+	next := chrono.BackoffConfig{
+		Initial: c.Initial,
+		Max:     c.Max,
+		Factor:  c.Factor,
+	}.NextAfter(prev)
+	return next, next != cur
+}
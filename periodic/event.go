@@ -0,0 +1,77 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package periodic
+
+import (
+	"time"
+)
+
+// An EventKind identifies the type of an [Event].
+type EventKind int
+
+// Event kinds emitted by a [Handle].
+const (
+	// EventRunStart is emitted immediately before a [Handle]'s [Func] is
+	// invoked.
+	EventRunStart EventKind = iota + 1
+	// EventRunEnd is emitted immediately after a [Handle]'s [Func] returns.
+	EventRunEnd
+	// EventSkip is emitted when a tick is received but the [Func] is not
+	// invoked because the [Handle] is stopping.
+	EventSkip
+	// EventError is emitted after an [EventRunEnd] event when the [Handle]'s
+	// [ErrFunc] returns an error.
+	EventError
+)
+
+// String returns a human-readable representation of k.
+func (k EventKind) String() string {
+	switch k {
+	case EventRunStart:
+		return "RunStart"
+	case EventRunEnd:
+		return "RunEnd"
+	case EventSkip:
+		return "Skip"
+	case EventError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// An Event describes something that happened within a [Handle]. Events are
+// delivered to the observer configured via [WithObserver].
+type Event struct {
+	// Name is the name of the [Handle], as configured via [WithName].
+	Name string
+	// Kind is the type of event.
+	Kind EventKind
+	// Time is the time at which the event occurred, according to the
+	// [Handle]'s clock.
+	Time time.Time
+	// Elapsed is the amount of time that the [Func] took to run. It is only
+	// set for [EventRunEnd] events.
+	Elapsed time.Duration
+	// Err is the error returned by the [Handle]'s [ErrFunc], if any. It is
+	// only set for [EventRunEnd] and [EventError] events.
+	Err error
+}
@@ -27,18 +27,51 @@ import (
 	"time"
 
 	"go.mway.dev/chrono/clock"
+	"go.uber.org/atomic"
 )
 
+// _freespin is a closed channel that is used in place of a ticker's channel
+// when a [Handle] has no period.
+var _freespin = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
 // A Func is a function that can be run periodically. A Func must abide by ctx.
 type Func = func(ctx context.Context)
 
+// A TimedFunc is like a [Func], but also receives the time of the tick that
+// caused it to run. A TimedFunc must abide by ctx.
+type TimedFunc = func(ctx context.Context, tick time.Time)
+
+// An ErrFunc is like a [Func], but reports whether it failed by returning an
+// error. Errors are reported to the [Handle]'s observer (see [WithObserver])
+// and drive its backoff (see [WithBackoff]). An ErrFunc must abide by ctx.
+type ErrFunc = func(ctx context.Context) error
+
+// handleFunc is the form of func that a [Handle] runs internally; all other
+// forms are adapted to it.
+type handleFunc = func(ctx context.Context, tick time.Time) error
+
 // A Handle manages a [Func] that is running periodically.
 type Handle struct {
-	fn     Func
-	ctx    context.Context
-	cancel context.CancelFunc
-	clock  clock.Clock
-	wg     sync.WaitGroup
+	fn       handleFunc
+	ctx      context.Context
+	cancel   context.CancelFunc
+	clock    clock.Clock
+	name     string
+	observer func(Event)
+	backoff  BackoffConfig
+	timeout  time.Duration
+	aligned  bool
+	period   atomic.Duration
+	running  atomic.Bool
+	periods  chan time.Duration
+	triggers chan struct{}
+	done     chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
 }
 
 // Start applies the given options and starts running fn every period until
@@ -57,21 +90,99 @@ func StartWithContext(
 	fn Func,
 	opts ...StartOption,
 ) *Handle {
+	return start(ctx, period, untimed(fn), opts...)
+}
+
+// StartTimed is like [Start], but fn also receives the time of each tick: the
+// time received from the underlying ticker, or the clock's current time if
+// period is <=0 or fn is being run via [Handle.Run].
+func StartTimed(
+	period time.Duration,
+	fn TimedFunc,
+	opts ...StartOption,
+) *Handle {
+	return StartTimedWithContext(context.Background(), period, fn, opts...)
+}
+
+// StartTimedWithContext is like [StartWithContext], but fn also receives the
+// time of each tick. See [StartTimed] for more information.
+func StartTimedWithContext(
+	ctx context.Context,
+	period time.Duration,
+	fn TimedFunc,
+	opts ...StartOption,
+) *Handle {
+	return start(ctx, period, infallible(fn), opts...)
+}
+
+// StartErr is like [Start], but runs an [ErrFunc], whose errors are reported
+// to the handle's observer and drive its backoff.
+func StartErr(period time.Duration, fn ErrFunc, opts ...StartOption) *Handle {
+	return StartErrWithContext(context.Background(), period, fn, opts...)
+}
+
+// StartErrWithContext is like [StartWithContext], but runs an [ErrFunc]. See
+// [StartErr] for more information.
+func StartErrWithContext(
+	ctx context.Context,
+	period time.Duration,
+	fn ErrFunc,
+	opts ...StartOption,
+) *Handle {
+	return start(ctx, period, untimedErr(fn), opts...)
+}
+
+func start(
+	ctx context.Context,
+	period time.Duration,
+	fn handleFunc,
+	opts ...StartOption,
+) *Handle {
+	options := DefaultOptions().With(opts...)
+	if options.Clock == _defaultOptions.Clock &&
+		(options.Aligned || !options.Deadline.IsZero()) {
+		options.Clock = _defaultWallClock
+	}
+
 	var (
-		options      = defaultStartOptions().With(opts...)
 		hctx, cancel = context.WithCancel(ctx)
 		h            = &Handle{
-			fn:     fn,
-			ctx:    hctx,
-			cancel: cancel,
-			clock:  options.Clock,
+			fn:       fn,
+			ctx:      hctx,
+			cancel:   cancel,
+			clock:    options.Clock,
+			name:     options.Name,
+			observer: options.Observer,
+			backoff:  options.Backoff,
+			timeout:  options.RunTimeout,
+			aligned:  options.Aligned,
+			periods:  make(chan time.Duration, 1),
+			triggers: make(chan struct{}, 1),
+			done:     make(chan struct{}),
 		}
 		ready = make(chan struct{})
 	)
 
+	if !options.Deadline.IsZero() {
+		// The deadline is converted to a duration using the clock's Now, since
+		// the clock's Nanotime may not be on the same epoch as wall time.
+		dctx, dcancel := h.clock.TimeoutContext(
+			hctx,
+			options.Deadline.Sub(h.clock.Now()),
+		)
+		h.ctx = dctx
+		h.cancel = func() {
+			dcancel()
+			cancel()
+		}
+	}
+
+	h.running.Store(true)
 	h.wg.Add(1)
 	go func() {
 		defer h.wg.Done()
+		defer close(h.done)
+		defer h.running.Store(false)
 		h.runLoop(period, ready)
 	}()
 
@@ -79,6 +190,21 @@ func StartWithContext(
 	return h
 }
 
+// Done returns a channel that is closed once h has stopped running its [Func],
+// either because its context expired, because its deadline passed (see
+// [WithDeadline]), or because [Handle.Stop] was called.
+func (h *Handle) Done() <-chan struct{} {
+	return h.done
+}
+
+// IsRunning reports whether h is still running its [Func] periodically. It
+// returns false once h's loop has exited, either because its context expired
+// or because [Handle.Stop] was called; by the time that the channel returned by
+// [Handle.Done] is closed, IsRunning is guaranteed to return false.
+func (h *Handle) IsRunning() bool {
+	return h.running.Load()
+}
+
 // Run runs the underlying [Func] with h's own [context.Context]. This call
 // does not affect the period at which h is already calling the func.
 func (h *Handle) Run() {
@@ -86,9 +212,109 @@ func (h *Handle) Run() {
 }
 
 // RunWithContext runs the underlying [Func] with ctx. This call does not
-// affect the period at which h is already calling the func.
+// affect the period at which h is already calling the func. If h was started
+// with [WithRunTimeout], the func receives a context derived from ctx that
+// expires after the configured timeout.
 func (h *Handle) RunWithContext(ctx context.Context) {
-	h.fn(ctx)
+	// Errors are reported to the observer by run; manual invocations do not
+	// affect backoff.
+	//nolint:errcheck
+	h.run(ctx, h.clock.Now())
+}
+
+// Period returns the period at which h is currently running its func, which
+// reflects any changes made via [Handle.SetPeriod] or by backoff (see
+// [WithBackoff]).
+func (h *Handle) Period() time.Duration {
+	return h.period.Load()
+}
+
+func (h *Handle) run(ctx context.Context, tick time.Time) error {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = h.clock.TimeoutContext(ctx, h.timeout)
+		defer cancel()
+	}
+
+	if h.observer == nil {
+		return h.loadFunc()(ctx, tick)
+	}
+
+	start := h.clock.Now()
+	h.emit(Event{
+		Kind: EventRunStart,
+		Time: start,
+	})
+
+	err := h.loadFunc()(ctx, tick)
+
+	end := h.clock.Now()
+	h.emit(Event{
+		Kind:    EventRunEnd,
+		Time:    end,
+		Elapsed: end.Sub(start),
+		Err:     err,
+	})
+
+	if err != nil {
+		h.emit(Event{
+			Kind: EventError,
+			Time: end,
+			Err:  err,
+		})
+	}
+
+	return err
+}
+
+// SetFunc replaces the [Func] being managed by h. Any invocation that is
+// already in progress completes with the previous func; all subsequent
+// invocations use fn. If fn is nil, SetFunc has no effect.
+func (h *Handle) SetFunc(fn Func) {
+	if fn == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fn = untimed(fn)
+}
+
+// SetPeriod changes the period at which h runs its [Func] to d, taking effect
+// on the loop's next iteration without restarting it. If d is <=0, the func
+// will be executed repeatedly without any delay. SetPeriod does not block, and
+// may be called from within the func itself; if SetPeriod is called multiple
+// times before the loop observes the change, only the latest period is used.
+func (h *Handle) SetPeriod(d time.Duration) {
+	for {
+		select {
+		case h.periods <- d:
+			return
+		default:
+		}
+
+		// Discard any pending period that the loop has not yet observed.
+		select {
+		case <-h.periods:
+		default:
+		}
+	}
+}
+
+// Trigger asks h to run its [Func] once more as soon as possible, in addition
+// to its periodic invocations, without waiting for the next tick. Trigger does
+// not block: the extra invocation happens on h's own goroutine, and so never
+// runs concurrently with its periodic invocations. Calls to Trigger made before
+// the loop observes a previous call are coalesced into a single invocation.
+// Like invocations via [Handle.Run], triggered invocations do not affect the
+// handle's period (see [WithBackoff]). Once h has stopped, Trigger has no
+// effect.
+func (h *Handle) Trigger() {
+	select {
+	case h.triggers <- struct{}{}:
+	default:
+	}
 }
 
 // Stop stops the [Func] being managed by h and waits for it to exit.
@@ -97,32 +323,160 @@ func (h *Handle) Stop() {
 	h.wg.Wait()
 }
 
-func (h *Handle) runLoop(period time.Duration, ready chan<- struct{}) {
-	var tick <-chan time.Time
-	if period > 0 {
-		ticker := h.clock.NewTicker(period)
-		defer ticker.Stop()
-		tick = ticker.C
-	} else {
-		tmp := make(chan time.Time)
-		close(tmp)
-		tick = tmp
+// StopContext is like [Handle.Stop], but gives up waiting for h's [Func] to
+// exit once ctx is done, in which case it returns ctx.Err(); otherwise, it
+// returns nil. This bounds shutdown when the func may not abide by its
+// context. Note that a StopContext that gives up leaves h's goroutine running
+// until the func returns: h has still been stopped, and will not run its func
+// again, but the caller must decide whether to keep waiting (e.g. via
+// [Handle.Done]) or to proceed without it.
+func (h *Handle) StopContext(ctx context.Context) error {
+	h.cancel()
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *Handle) emit(ev Event) {
+	if h.observer == nil {
+		return
 	}
 
+	ev.Name = h.name
+	h.observer(ev)
+}
+
+func (h *Handle) loadFunc() handleFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.fn
+}
+
+func (h *Handle) runLoop(period time.Duration, ready chan<- struct{}) {
+	var ticker *clock.Ticker
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+
+	tick := h.resetTicker(&ticker, period)
 	close(ready)
 
 	for {
+		// Prioritize period changes so that a change made by the func itself
+		// is applied before the next tick is considered.
+		select {
+		case d := <-h.periods:
+			tick = h.resetTicker(&ticker, d)
+		default:
+		}
+
 		select {
 		case <-h.ctx.Done():
 			return
-		case <-tick:
-			select {
-			case <-h.ctx.Done():
+		case d := <-h.periods:
+			tick = h.resetTicker(&ticker, d)
+		case <-h.triggers:
+			if h.skipIfDone() {
+				return
+			}
+
+			//nolint:errcheck
+			h.run(h.ctx, h.clock.Now())
+		case ts, ok := <-tick:
+			// Freespinning handles have no ticker to report the time.
+			if !ok {
+				ts = h.clock.Now()
+			}
+
+			if h.skipIfDone() {
 				return
-			default:
 			}
 
-			h.RunWithContext(h.ctx)
+			err := h.run(h.ctx, ts)
+			if next, changed := h.backoff.next(h.Period(), err); changed {
+				tick = h.resetTicker(&ticker, next)
+			}
+		}
+	}
+}
+
+// skipIfDone reports whether h's context is done, emitting an [EventSkip] for
+// the invocation that will not happen if so.
+func (h *Handle) skipIfDone() bool {
+	select {
+	case <-h.ctx.Done():
+		h.emit(Event{
+			Kind: EventSkip,
+			Time: h.clock.Now(),
+		})
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *Handle) resetTicker(
+	ticker **clock.Ticker,
+	period time.Duration,
+) <-chan time.Time {
+	// Publish the period only once the ticker reflects it.
+	defer h.period.Store(period)
+
+	if period <= 0 {
+		if *ticker != nil {
+			(*ticker).Stop()
+		}
+		return _freespin
+	}
+
+	// Aligned tickers are recreated so that they align to the new period.
+	if h.aligned {
+		if *ticker != nil {
+			(*ticker).Stop()
 		}
+		*ticker = h.clock.NewAlignedTicker(period)
+		return (*ticker).C
+	}
+
+	if *ticker == nil {
+		*ticker = h.clock.NewTicker(period)
+		return (*ticker).C
+	}
+
+	(*ticker).Reset(period)
+
+	// Discard any tick that was delivered under the previous period.
+	select {
+	case <-(*ticker).C:
+	default:
+	}
+
+	return (*ticker).C
+}
+
+func untimed(fn Func) handleFunc {
+	return func(ctx context.Context, _ time.Time) error {
+		fn(ctx)
+		return nil
+	}
+}
+
+func untimedErr(fn ErrFunc) handleFunc {
+	return func(ctx context.Context, _ time.Time) error {
+		return fn(ctx)
+	}
+}
+
+func infallible(fn TimedFunc) handleFunc {
+	return func(ctx context.Context, tick time.Time) error {
+		fn(ctx, tick)
+		return nil
 	}
 }
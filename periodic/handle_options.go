@@ -21,46 +21,213 @@
 package periodic
 
 import (
+	"time"
+
 	"go.mway.dev/chrono/clock"
 )
 
-var _defaultStartOptions = startOptions{
-	Clock: clock.NewMonotonicClock(),
-}
+var (
+	_ StartOption = Options{}
+
+	_defaultOptions = Options{
+		Clock: clock.NewMonotonicClock(),
+	}
+
+	// _defaultWallClock replaces the default clock for handles that need wall
+	// time, i.e. those configured with [WithAlignment] or [WithDeadline].
+	_defaultWallClock = clock.NewHybridClock()
+)
 
-type startOptions struct {
+// Options configure a [Handle]. Options may be passed directly to [Start] as a
+// [StartOption]; only non-zero fields are applied.
+type Options struct {
+	// Clock configures the [clock.Clock] used for measuring time. By default,
+	// a monotonic clock is used; if the handle is aligned or has a deadline,
+	// the default is instead a [clock.NewHybridClock], which measures periods
+	// using monotonic time but reports and aligns to wall time.
 	Clock clock.Clock
+	// RunTimeout bounds each invocation of the [Handle]'s [Func]. See
+	// [WithRunTimeout] for more information.
+	RunTimeout time.Duration
+	// Name configures the name of the [Handle], which is included in all of
+	// the [Event]s that it emits.
+	Name string
+	// Observer configures a function that receives the [Event]s emitted by
+	// the [Handle]. See [WithObserver] for more information.
+	Observer func(Event)
+	// Backoff configures the [Handle]'s period to change in response to the
+	// results of its func. See [WithBackoff] for more information.
+	Backoff BackoffConfig
+	// Deadline configures the time at which the [Handle] stops itself. See
+	// [WithDeadline] for more information.
+	Deadline time.Time
+	// Aligned configures the [Handle]'s ticks to fall on multiples of its
+	// period. See [WithAlignment] for more information.
+	Aligned bool
 }
 
-func defaultStartOptions() startOptions {
-	return _defaultStartOptions
+// DefaultOptions returns a new [Options] with sane defaults.
+func DefaultOptions() Options {
+	return _defaultOptions
 }
 
-// With returns a new [StartOptions] with opts merged on top of o.
-func (o startOptions) With(opts ...StartOption) startOptions {
+// With returns a new [Options] with opts merged on top of o.
+func (o Options) With(opts ...StartOption) Options {
 	for _, opt := range opts {
 		opt.apply(&o)
 	}
 	return o
 }
 
+func (o Options) apply(dst *Options) {
+	if o.Clock != nil {
+		dst.Clock = o.Clock
+	}
+
+	if o.RunTimeout > 0 {
+		dst.RunTimeout = o.RunTimeout
+	}
+
+	if len(o.Name) > 0 {
+		dst.Name = o.Name
+	}
+
+	if o.Observer != nil {
+		dst.Observer = o.Observer
+	}
+
+	if o.Backoff.enabled() {
+		dst.Backoff = o.Backoff
+	}
+
+	if !o.Deadline.IsZero() {
+		dst.Deadline = o.Deadline
+	}
+
+	if o.Aligned {
+		dst.Aligned = true
+	}
+}
+
 // A StartOption is passed to [Start] to configure a [Handle].
 type StartOption interface {
-	apply(*startOptions)
+	apply(*Options)
 }
 
 // WithClock returns a [StartOption] that configures a [Handle] to use the
 // given [clock.Clock] for measuring time.
 func WithClock(clk clock.Clock) StartOption {
-	return startOptionFunc(func(dst *startOptions) {
+	return startOptionFunc(func(dst *Options) {
 		if clk != nil {
 			dst.Clock = clk
 		}
 	})
 }
 
-type startOptionFunc func(*startOptions)
+// WithNanotimeFunc returns a [StartOption] that configures a [Handle] to use a
+// monotonic [clock.Clock] that tells time using fn, as if created by
+// [clock.NewClock] with [clock.WithNanotimeFunc]. If fn is nil, the option has
+// no effect.
+func WithNanotimeFunc(fn clock.NanotimeFunc) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		if fn != nil {
+			dst.Clock = clock.MustClock(clock.NewClock(clock.WithNanotimeFunc(fn)))
+		}
+	})
+}
+
+// WithRunTimeout returns a [StartOption] that bounds each invocation of a
+// [Handle]'s [Func] by d. Each invocation receives its own context, derived
+// from the context it would otherwise have been given, that expires once the
+// handle's [clock.Clock] has advanced by d (see [clock.Clock.TimeoutContext]),
+// and is canceled once the func returns. If d <= 0, the option has no effect.
+func WithRunTimeout(d time.Duration) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		if d > 0 {
+			dst.RunTimeout = d
+		}
+	})
+}
+
+// WithName returns a [StartOption] that configures the name of a [Handle]. The
+// name is included in all [Event]s emitted by the handle.
+func WithName(name string) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		dst.Name = name
+	})
+}
+
+// WithObserver returns a [StartOption] that configures a [Handle] to deliver
+// the [Event]s that it emits to fn, e.g. for logging or metrics. fn is called
+// synchronously on the goroutine invoking the handle's [Func], and so should
+// not block.
+func WithObserver(fn func(Event)) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		if fn != nil {
+			dst.Observer = fn
+		}
+	})
+}
+
+// WithBackoff returns a [StartOption] that configures a [Handle] to back off
+// while its func is failing: after each failed invocation of an [ErrFunc], the
+// handle's period is multiplied by cfg.Factor, up to cfg.Max; after each
+// successful invocation, the period is reset to cfg.Initial. The ticker is
+// reset whenever the period changes, so the next invocation happens one new
+// period after the previous invocation finished. Invocations via [Handle.Run]
+// do not affect the period. Funcs that cannot fail, such as a [Func], are
+// always considered to have succeeded. If cfg.Initial <= 0, the option has no
+// effect.
+func WithBackoff(cfg BackoffConfig) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		if cfg.enabled() {
+			dst.Backoff = cfg
+		}
+	})
+}
+
+// WithDeadline returns a [StartOption] that configures a [Handle] to stop itself
+// once its clock reaches t, as if [Handle.Stop] had been called. When the
+// handle starts, t is compared to its [clock.Clock]'s Now (see [WithClock]),
+// and the handle stops once the clock has advanced by the difference, so the
+// deadline can be tested with a [clock.FakeClock]. If no clock is configured,
+// a handle with a deadline uses a [clock.NewHybridClock] rather than the
+// default monotonic clock, so wall-time deadlines work as expected; monotonic
+// clocks, such as those created by [WithNanotimeFunc], do not, and should only
+// be given deadlines in their own time. If t is the zero time, the option has
+// no effect.
+func WithDeadline(t time.Time) StartOption {
+	return startOptionFunc(func(dst *Options) {
+		if !t.IsZero() {
+			dst.Deadline = t
+		}
+	})
+}
+
+// WithAlignment returns a [StartOption] that configures a [Handle] to align its
+// ticks to multiples of its period, as measured from the epoch of the handle's
+// [clock.Clock] (see [clock.Clock.NewAlignedTicker]): the first tick is delayed
+// until the next multiple of the period, so that e.g. a handle with a period of
+// one minute runs every minute on the minute. Whenever the period changes,
+// whether via [Handle.SetPeriod] or backoff (see [WithBackoff]), ticks are
+// realigned to the new period. Freespinning handles are unaffected.
+//
+// Ticks are only aligned to wall time if the handle's clock aligns its tickers
+// to wall time, as wall clocks (e.g. [clock.NewWallClock]) do. If no clock is
+// configured, an aligned handle uses a [clock.NewHybridClock] rather than the
+// default monotonic clock, and so aligns to wall time. Monotonic clocks, such
+// as [clock.NewMonotonicClock] and clocks created by [WithNanotimeFunc], align
+// to their own epoch, which is arbitrary (e.g. the time at which the system
+// booted), so with such clocks a handle with a period of one minute runs once
+// per minute, but not on the minute.
+func WithAlignment() StartOption {
+	return startOptionFunc(func(dst *Options) {
+		dst.Aligned = true
+	})
+}
+
+type startOptionFunc func(*Options)
 
-func (f startOptionFunc) apply(dst *startOptions) {
+func (f startOptionFunc) apply(dst *Options) {
 	f(dst)
 }
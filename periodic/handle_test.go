@@ -22,11 +22,13 @@ package periodic_test
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono"
 	"go.mway.dev/chrono/clock"
 	"go.mway.dev/chrono/periodic"
 	"go.mway.dev/x/channels"
@@ -217,6 +219,53 @@ func TestStartWithContext_ContextPreCanceled(t *testing.T) {
 	}
 }
 
+func TestStartTimed(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		ticks  = make(chan time.Time, 1)
+		handle = periodic.StartTimed(
+			time.Second,
+			func(_ context.Context, tick time.Time) {
+				ticks <- tick
+			},
+			periodic.WithClock(clk),
+		)
+	)
+	defer handle.Stop()
+
+	// Ticks are reported with the time sent by the ticker.
+	for i := 1; i <= 3; i++ {
+		clk.Add(time.Second)
+		require.Equal(t, time.Unix(0, int64(i)*int64(time.Second)), <-ticks)
+	}
+
+	// Manual runs are reported with the clock's current time.
+	clk.SetNanotime(int64(3*time.Second) + 1)
+	handle.Run()
+	require.Equal(t, clk.Now(), <-ticks)
+
+	// Freespinning handles report the clock's current time.
+	handle.SetPeriod(0)
+	require.Equal(t, clk.Now(), <-ticks)
+	handle.SetPeriod(time.Hour)
+	waitForQuiet(t, ticks)
+}
+
+func TestStartTimedWithContext(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		handle      = periodic.StartTimedWithContext(
+			ctx,
+			time.Hour,
+			func(context.Context, time.Time) {},
+		)
+	)
+	defer handle.Stop()
+
+	cancel()
+	requireDone(t, handle)
+}
+
 func TestHandle_Run(t *testing.T) {
 	var (
 		called = make(chan struct{}, 1)
@@ -246,6 +295,661 @@ func TestHandle_Run(t *testing.T) {
 	}
 }
 
+func TestHandle_Done(t *testing.T) {
+	t.Run("stop", func(t *testing.T) {
+		handle := periodic.Start(time.Hour, func(context.Context) {})
+		requireNotDone(t, handle)
+		require.True(t, handle.IsRunning())
+
+		handle.Stop()
+		requireDone(t, handle)
+		require.False(t, handle.IsRunning())
+
+		// Stopping again has no effect.
+		handle.Stop()
+		require.False(t, handle.IsRunning())
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		handle := periodic.StartWithContext(
+			ctx,
+			time.Hour,
+			func(context.Context) {},
+		)
+		defer handle.Stop()
+
+		requireNotDone(t, handle)
+		require.True(t, handle.IsRunning())
+		cancel()
+		requireDone(t, handle)
+		require.False(t, handle.IsRunning())
+	})
+}
+
+func TestHandle_StopContext(t *testing.T) {
+	var (
+		started = make(chan struct{}, 1)
+		release = make(chan struct{})
+		handle  = periodic.Start(
+			time.Hour,
+			func(context.Context) {
+				started <- struct{}{}
+				<-release
+			},
+		)
+	)
+	defer handle.Stop()
+
+	// Trigger an invocation that ignores its context.
+	handle.Trigger()
+	requireRecvWithTimeout(t, started, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, handle.StopContext(ctx), context.DeadlineExceeded)
+	require.True(t, handle.IsRunning())
+
+	// Once the func returns, the handle finishes stopping.
+	close(release)
+	require.NoError(t, handle.StopContext(context.Background()))
+	require.False(t, handle.IsRunning())
+	requireDone(t, handle)
+}
+
+func TestHandle_SetFunc(t *testing.T) {
+	var (
+		started = make(chan struct{}, 1)
+		calledA = make(chan struct{}, 1)
+		calledB = make(chan struct{}, 1)
+		release = make(chan struct{})
+		clk     = clock.NewFakeClock()
+		handle  = periodic.Start(
+			time.Second,
+			func(ctx context.Context) {
+				started <- struct{}{}
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return
+				}
+				calledA <- struct{}{}
+			},
+			periodic.WithClock(clk),
+		)
+	)
+
+	defer handle.Stop()
+
+	// Start an invocation of the original func, and swap the func while it is
+	// still in progress.
+	clk.Add(time.Second)
+	requireRecvWithTimeout(t, started, time.Second)
+	handle.SetFunc(func(context.Context) {
+		calledB <- struct{}{}
+	})
+
+	// The in-progress invocation should complete with the original func.
+	close(release)
+	requireRecvWithTimeout(t, calledA, time.Second)
+
+	// All subsequent invocations should use the new func.
+	for i := 0; i < 3; i++ {
+		clk.Add(time.Second)
+		requireRecvWithTimeout(t, calledB, time.Second)
+		require.False(t, recvWithTimeout(calledA, 10*time.Millisecond))
+	}
+
+	// A nil func is ignored.
+	handle.SetFunc(nil)
+	handle.Run()
+	requireRecvWithTimeout(t, calledB, time.Second)
+}
+
+func TestHandle_Trigger(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		calls   = make(chan struct{}, 10)
+		release = make(chan struct{}, 10)
+		handle  = periodic.Start(
+			time.Hour,
+			func(context.Context) {
+				calls <- struct{}{}
+				<-release
+			},
+			periodic.WithClock(clk),
+		)
+	)
+
+	// Triggering runs the func without waiting for a tick.
+	handle.Trigger()
+	requireRecvWithTimeout(t, calls, time.Second)
+
+	// Triggers made while the func is running are coalesced.
+	for i := 0; i < 3; i++ {
+		handle.Trigger()
+	}
+	release <- struct{}{}
+	requireRecvWithTimeout(t, calls, time.Second)
+	release <- struct{}{}
+	require.False(t, recvWithTimeout(calls, 50*time.Millisecond))
+
+	// Triggering a stopped handle has no effect.
+	handle.Stop()
+	handle.Trigger()
+	require.False(t, recvWithTimeout(calls, 10*time.Millisecond))
+}
+
+func TestWithDeadline(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		called = make(chan struct{}, 1)
+		handle = periodic.Start(
+			time.Second,
+			func(context.Context) {
+				called <- struct{}{}
+			},
+			periodic.WithClock(clk),
+			periodic.WithDeadline(time.Unix(0, int64(3*time.Second)+1)),
+		)
+	)
+	defer handle.Stop()
+
+	// The func runs periodically until the clock passes the deadline.
+	for i := 0; i < 3; i++ {
+		clk.Add(time.Second)
+		requireRecvWithTimeout(t, called, time.Second)
+		require.True(t, handle.IsRunning())
+	}
+
+	clk.Add(time.Second)
+	requireDone(t, handle)
+	require.False(t, handle.IsRunning())
+}
+
+func TestWithDeadline_DefaultClock(t *testing.T) {
+	// Wall-time deadlines are honored by the default clock.
+	handle := periodic.Start(
+		time.Hour,
+		func(context.Context) {},
+		periodic.WithDeadline(time.Now().Add(10*time.Millisecond)),
+	)
+	defer handle.Stop()
+
+	requireDone(t, handle)
+}
+
+func TestWithDeadline_Zero(t *testing.T) {
+	require.Equal(
+		t,
+		periodic.DefaultOptions(),
+		periodic.DefaultOptions().With(periodic.WithDeadline(time.Time{})),
+	)
+
+	deadline := time.Unix(123, 0)
+	require.Equal(
+		t,
+		deadline,
+		periodic.DefaultOptions().With(periodic.Options{Deadline: deadline}).Deadline,
+	)
+}
+
+func TestWithAlignment_DefaultClock(t *testing.T) {
+	// The default clock is monotonic.
+	clk := periodic.DefaultOptions().Clock
+	require.InDelta(t, chrono.Nanotime(), clk.Now().UnixNano(), float64(time.Second))
+
+	// Aligned handles without a configured clock use wall time instead, so that
+	// they align to wall time rather than to an arbitrary monotonic epoch.
+	ticks := make(chan time.Time, 1)
+	handle := periodic.StartTimed(
+		time.Hour,
+		func(_ context.Context, tick time.Time) {
+			select {
+			case ticks <- tick:
+			default:
+			}
+		},
+		periodic.WithAlignment(),
+	)
+	defer handle.Stop()
+
+	handle.Run()
+	require.WithinDuration(t, time.Now(), <-ticks, time.Minute)
+}
+
+func TestWithAlignment(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		ticks = make(chan time.Time, 1)
+	)
+
+	// Start 90s into a minute.
+	clk.SetTime(time.Unix(90, 0))
+
+	handle := periodic.StartTimed(
+		time.Minute,
+		func(_ context.Context, tick time.Time) {
+			ticks <- tick
+		},
+		periodic.WithClock(clk),
+		periodic.WithAlignment(),
+	)
+	defer handle.Stop()
+
+	// The first tick is delayed until the next minute.
+	clk.Add(29 * time.Second)
+	require.False(t, recvWithTimeout(ticks, 10*time.Millisecond))
+	clk.Add(time.Second)
+	require.Equal(t, time.Unix(120, 0), <-ticks)
+
+	// Subsequent ticks fall on every minute.
+	for i := 3; i <= 5; i++ {
+		clk.Add(time.Minute)
+		require.Equal(t, time.Unix(int64(i)*60, 0), <-ticks)
+	}
+
+	// Changing the period realigns the ticks.
+	handle.SetPeriod(time.Hour)
+	waitForPeriod(t, handle, time.Hour)
+	clk.SetTime(time.Unix(3599, 0))
+	require.False(t, recvWithTimeout(ticks, 10*time.Millisecond))
+	clk.Add(time.Second)
+	require.Equal(t, time.Unix(3600, 0), <-ticks)
+}
+
+func TestHandle_RunTimeout(t *testing.T) {
+	var (
+		ctxs   = make(chan context.Context, 2)
+		handle = periodic.Start(
+			time.Hour,
+			func(ctx context.Context) {
+				_, hasDeadline := ctx.Deadline()
+				require.True(t, hasDeadline)
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+					require.Fail(t, "run context did not time out")
+				}
+				require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+
+				ctxs <- ctx
+			},
+			periodic.WithRunTimeout(10*time.Millisecond),
+		)
+	)
+
+	defer handle.Stop()
+
+	handle.Run()
+	handle.Run()
+
+	// Each invocation should receive its own context.
+	first, second := <-ctxs, <-ctxs
+	require.NotEqual(t, first, second)
+}
+
+func TestHandle_RunTimeout_FakeClock(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		started = make(chan context.Context, 1)
+		errs    = make(chan error, 1)
+		handle  = periodic.Start(
+			time.Hour,
+			func(ctx context.Context) {
+				started <- ctx
+				<-ctx.Done()
+				errs <- ctx.Err()
+			},
+			periodic.WithClock(clk),
+			periodic.WithRunTimeout(time.Minute),
+		)
+	)
+	defer handle.Stop()
+
+	go handle.Run()
+	ctx := <-started
+
+	// The timeout is measured by the handle's clock, not in real time.
+	clk.Add(time.Minute - 1)
+	require.NoError(t, ctx.Err())
+	clk.Add(1)
+	require.ErrorIs(t, <-errs, context.DeadlineExceeded)
+}
+
+func TestHandle_RunTimeout_Disabled(t *testing.T) {
+	for _, d := range []time.Duration{0, -1} {
+		var (
+			ctxs   = make(chan context.Context, 1)
+			handle = periodic.Start(
+				time.Hour,
+				func(ctx context.Context) {
+					ctxs <- ctx
+				},
+				periodic.WithRunTimeout(d),
+			)
+		)
+
+		handle.Run()
+		ctx := <-ctxs
+		_, hasDeadline := ctx.Deadline()
+		require.False(t, hasDeadline)
+		require.NoError(t, ctx.Err())
+		handle.Stop()
+	}
+}
+
+func TestHandle_SetPeriod(t *testing.T) {
+	var (
+		called = make(chan struct{}, 1)
+		calls  atomic.Int64
+		clk    = clock.NewFakeClock()
+		handle *periodic.Handle
+	)
+
+	handle = periodic.Start(
+		time.Second,
+		func(context.Context) {
+			// Slow down after the first call.
+			if calls.Add(1) == 1 {
+				handle.SetPeriod(3 * time.Second)
+			}
+			called <- struct{}{}
+		},
+		periodic.WithClock(clk),
+	)
+	defer handle.Stop()
+
+	clk.Add(time.Second)
+	requireRecvWithTimeout(t, called, time.Second)
+
+	// The old period should no longer cause ticks.
+	for i := 0; i < 2; i++ {
+		clk.Add(time.Second)
+		require.False(t, recvWithTimeout(called, 10*time.Millisecond))
+	}
+
+	// The new period should.
+	for i := 0; i < 3; i++ {
+		clk.Add(3 * time.Second)
+		requireRecvWithTimeout(t, called, time.Second)
+		require.False(t, recvWithTimeout(called, 10*time.Millisecond))
+	}
+
+	// Switching to freespin should run the func without advancing the clock.
+	handle.SetPeriod(0)
+	for i := 0; i < 10; i++ {
+		requireRecvWithTimeout(t, called, time.Second)
+	}
+
+	// Switching back to a period should stop the freespin.
+	handle.SetPeriod(time.Hour)
+	waitForQuiet(t, called)
+	clk.Add(time.Hour)
+	requireRecvWithTimeout(t, called, time.Second)
+	require.False(t, recvWithTimeout(called, 10*time.Millisecond))
+}
+
+func TestHandle_Observer(t *testing.T) {
+	var (
+		clk    = clock.NewFakeClock()
+		events = make(chan periodic.Event, 8)
+		handle = periodic.Start(
+			time.Hour,
+			func(context.Context) {
+				clk.Add(5 * time.Second)
+			},
+			periodic.WithClock(clk),
+			periodic.WithName("test"),
+			periodic.WithObserver(func(ev periodic.Event) {
+				events <- ev
+			}),
+		)
+	)
+	defer handle.Stop()
+
+	start := clk.Now()
+	handle.Run()
+
+	require.Equal(
+		t,
+		periodic.Event{
+			Name: "test",
+			Kind: periodic.EventRunStart,
+			Time: start,
+		},
+		<-events,
+	)
+	require.Equal(
+		t,
+		periodic.Event{
+			Name:    "test",
+			Kind:    periodic.EventRunEnd,
+			Time:    start.Add(5 * time.Second),
+			Elapsed: 5 * time.Second,
+		},
+		<-events,
+	)
+	require.False(t, recvWithTimeout(events, 10*time.Millisecond))
+}
+
+func TestHandle_Observer_Nil(t *testing.T) {
+	var (
+		called = make(chan struct{}, 1)
+		handle = periodic.Start(
+			time.Hour,
+			func(context.Context) {
+				called <- struct{}{}
+			},
+			periodic.WithObserver(nil),
+		)
+	)
+	defer handle.Stop()
+
+	handle.Run()
+	requireRecvWithTimeout(t, called, time.Second)
+}
+
+func TestEventKind_String(t *testing.T) {
+	cases := map[periodic.EventKind]string{
+		periodic.EventRunStart: "RunStart",
+		periodic.EventRunEnd:   "RunEnd",
+		periodic.EventSkip:     "Skip",
+		periodic.EventError:    "Error",
+		periodic.EventKind(0):  "Unknown",
+	}
+
+	for kind, want := range cases {
+		require.Equal(t, want, kind.String())
+	}
+}
+
+func TestStartErr_Backoff(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		results = make(chan error, 16)
+		called  = make(chan struct{}, 1)
+		errTest = errors.New("test")
+		handle  = periodic.StartErr(
+			time.Second,
+			func(context.Context) error {
+				defer func() {
+					called <- struct{}{}
+				}()
+				return <-results
+			},
+			periodic.WithClock(clk),
+			periodic.WithBackoff(periodic.BackoffConfig{
+				Initial: time.Second,
+				Max:     8 * time.Second,
+				Factor:  2,
+			}),
+		)
+	)
+	defer handle.Stop()
+
+	require.Equal(t, time.Second, handle.Period())
+
+	// Each failure doubles the period, up to the max; a success resets it.
+	steps := []struct {
+		err  error
+		want time.Duration
+	}{
+		{err: errTest, want: 2 * time.Second},
+		{err: errTest, want: 4 * time.Second},
+		{err: errTest, want: 8 * time.Second},
+		{err: errTest, want: 8 * time.Second},
+		{err: nil, want: time.Second},
+		{err: errTest, want: 2 * time.Second},
+	}
+
+	period := handle.Period()
+	for _, step := range steps {
+		results <- step.err
+
+		// The func should not run before the current period has elapsed.
+		clk.Add(period - 1)
+		require.False(t, recvWithTimeout(called, 10*time.Millisecond))
+		clk.Add(1)
+		requireRecvWithTimeout(t, called, time.Second)
+
+		waitForPeriod(t, handle, step.want)
+		period = step.want
+	}
+}
+
+func TestStartErr_Observer(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		events  = make(chan periodic.Event, 8)
+		errTest = errors.New("test")
+		handle  = periodic.StartErrWithContext(
+			context.Background(),
+			time.Hour,
+			func(context.Context) error {
+				return errTest
+			},
+			periodic.WithClock(clk),
+			periodic.WithObserver(func(ev periodic.Event) {
+				events <- ev
+			}),
+		)
+	)
+	defer handle.Stop()
+
+	// Manual runs report errors, but do not affect the period.
+	handle.Run()
+	require.Equal(t, periodic.EventRunStart, (<-events).Kind)
+
+	ev := <-events
+	require.Equal(t, periodic.EventRunEnd, ev.Kind)
+	require.ErrorIs(t, ev.Err, errTest)
+
+	ev = <-events
+	require.Equal(t, periodic.EventError, ev.Kind)
+	require.ErrorIs(t, ev.Err, errTest)
+	require.Equal(t, time.Hour, handle.Period())
+}
+
+func TestBackoff_Defaults(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		handle  *periodic.Handle
+		called  = make(chan struct{}, 1)
+		errTest = errors.New("test")
+	)
+
+	// Without a max or a valid factor, failures double the period without
+	// bound.
+	handle = periodic.StartErr(
+		time.Second,
+		func(context.Context) error {
+			called <- struct{}{}
+			return errTest
+		},
+		periodic.WithClock(clk),
+		periodic.Options{
+			Backoff: periodic.BackoffConfig{
+				Initial: time.Second,
+			},
+		},
+	)
+	defer handle.Stop()
+
+	period := time.Second
+	for i := 0; i < 5; i++ {
+		clk.Add(period)
+		requireRecvWithTimeout(t, called, time.Second)
+		period *= 2
+		waitForPeriod(t, handle, period)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	clk := clock.NewFakeClock()
+
+	require.Equal(t, periodic.DefaultOptions(), periodic.DefaultOptions().With())
+	require.Equal(
+		t,
+		periodic.Options{
+			Clock:      clk,
+			RunTimeout: time.Second,
+			Name:       "test",
+		},
+		periodic.DefaultOptions().With(
+			periodic.Options{Clock: clk},
+			periodic.Options{RunTimeout: time.Second},
+			periodic.Options{Name: "test"},
+			periodic.Options{},
+		),
+	)
+
+	var (
+		called = make(chan struct{}, 1)
+		handle = periodic.Start(
+			time.Second,
+			func(context.Context) {
+				called <- struct{}{}
+			},
+			periodic.Options{Clock: clk},
+		)
+	)
+	defer handle.Stop()
+
+	clk.Add(time.Second)
+	requireRecvWithTimeout(t, called, time.Second)
+}
+
+func TestWithNanotimeFunc(t *testing.T) {
+	options := periodic.DefaultOptions().With(periodic.WithNanotimeFunc(nil))
+	require.Equal(t, periodic.DefaultOptions(), options)
+
+	options = periodic.DefaultOptions().With(
+		periodic.WithNanotimeFunc(func() int64 {
+			return 123
+		}),
+	)
+	require.EqualValues(t, 123, options.Clock.Nanotime())
+}
+
+func waitForPeriod(t *testing.T, handle *periodic.Handle, want time.Duration) {
+	timeout := time.After(time.Second)
+	for handle.Period() != want {
+		select {
+		case <-timeout:
+			require.FailNow(
+				t,
+				"timed out waiting for period",
+				"want %v, have %v",
+				want,
+				handle.Period(),
+			)
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
 func recvWithTimeout[T any](ch <-chan T, timeout time.Duration) bool {
 	_, ok := channels.RecvWithTimeout(context.Background(), ch, timeout)
 	return ok
@@ -258,3 +962,32 @@ func requireRecvWithTimeout[T any](
 ) {
 	require.True(t, recvWithTimeout(ch, timeout))
 }
+
+func requireDone(t *testing.T, handle *periodic.Handle) {
+	select {
+	case <-handle.Done():
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for handle to finish")
+	}
+}
+
+func requireNotDone(t *testing.T, handle *periodic.Handle) {
+	select {
+	case <-handle.Done():
+		require.FailNow(t, "handle finished unexpectedly")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func waitForQuiet[T any](t *testing.T, ch <-chan T) {
+	timeout := time.NewTimer(time.Second)
+	defer timeout.Stop()
+
+	for recvWithTimeout(ch, 10*time.Millisecond) {
+		select {
+		case <-timeout.C:
+			require.FailNow(t, "channel did not go quiet")
+		default:
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"sync/atomic"
+)
+
+// An AtomicRate holds a [Rate] that can be published and read concurrently
+// without locking, e.g. to publish the rate computed by [StartPeriodicReset]
+// to goroutines that report it. The zero value is ready to use, and holds a
+// zero Rate. An AtomicRate must not be copied after first use.
+type AtomicRate struct {
+	rate atomic.Pointer[Rate]
+}
+
+// Load returns the most recently stored [Rate], or a zero Rate if none has
+// been stored.
+func (r *AtomicRate) Load() Rate {
+	if rate := r.rate.Load(); rate != nil {
+		return *rate
+	}
+	return Rate{}
+}
+
+// Store replaces the held [Rate] with rate.
+func (r *AtomicRate) Store(rate Rate) {
+	r.rate.Store(&rate)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+	"go.uber.org/atomic"
+)
+
+func TestAtomicRate(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(rate.WithClock(clk))
+		latest   rate.AtomicRate
+	)
+
+	require.Equal(t, rate.Rate{}, latest.Load())
+	require.True(t, latest.Load().IsZero())
+
+	clk.Add(time.Second)
+	recorder.Add(100)
+	latest.Store(recorder.Reset())
+	require.EqualValues(t, 100, latest.Load().Per(time.Second))
+
+	clk.Add(time.Second)
+	recorder.Add(200)
+	latest.Store(recorder.Reset())
+	require.EqualValues(t, 200, latest.Load().Per(time.Second))
+}
+
+func TestAtomicRate_Concurrent(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(rate.WithClock(clk))
+		latest   rate.AtomicRate
+		torn     atomic.Int64
+		wg       sync.WaitGroup
+	)
+
+	// Every stored rate is 1/ns, so concurrent loads must never observe a
+	// count from one rate combined with the elapsed time of another.
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			clk.Add(time.Duration(i))
+			recorder.Add(i)
+			latest.Store(recorder.Reset())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if current := latest.Load(); !current.IsZero() &&
+				current.Per(time.Nanosecond) != 1 {
+				torn.Inc()
+			}
+		}
+	}()
+	wg.Wait()
+
+	require.Zero(t, torn.Load())
+
+	require.EqualValues(t, 1, latest.Load().Per(time.Nanosecond))
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mway.dev/chrono/clock"
+)
+
+// _reservoirSize is the maximum number of samples retained by a
+// [DistRecorder].
+const _reservoirSize = 1024
+
+// A DistRecorder records observed values and reports statistics about their
+// distribution since the recorder was last reset. The mean is exact, while
+// quantiles are estimated from a fixed-size uniform reservoir sample of the
+// observed values.
+type DistRecorder struct {
+	clock   clock.Clock
+	rng     *rand.Rand
+	samples []float64
+	count   int64
+	sum     float64
+	epoch   int64
+	mu      sync.Mutex
+}
+
+// NewDistRecorder creates a new [DistRecorder] configured by the given
+// options.
+func NewDistRecorder(opts ...Option) *DistRecorder {
+	options := DefaultOptions().With(opts...)
+	r := &DistRecorder{
+		clock:   options.Clock,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		samples: make([]float64, 0, _reservoirSize),
+	}
+	r.Reset()
+	return r
+}
+
+// Observe records v.
+func (r *DistRecorder) Observe(v float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.count++
+	r.sum += v
+
+	if len(r.samples) < _reservoirSize {
+		r.samples = append(r.samples, v)
+		return
+	}
+
+	// Replace an existing sample with decreasing probability so that every
+	// observed value is equally likely to be retained.
+	if i := r.rng.Int63n(r.count); i < _reservoirSize {
+		r.samples[i] = v
+	}
+}
+
+// Count returns the number of values observed since the recorder was last
+// reset.
+func (r *DistRecorder) Count() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Elapsed returns the time elapsed since the recorder was last reset.
+func (r *DistRecorder) Elapsed() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clock.SinceNanotime(r.epoch)
+}
+
+// Mean returns the mean of all values observed since the recorder was last
+// reset, or 0 if no values have been observed.
+func (r *DistRecorder) Mean() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.count == 0 {
+		return 0
+	}
+	return r.sum / float64(r.count)
+}
+
+// Quantile returns an estimate of the q-quantile of the values observed since
+// the recorder was last reset, interpolating linearly between samples; q is
+// clamped to [0, 1]. If no values have been observed, Quantile returns 0.
+func (r *DistRecorder) Quantile(q float64) float64 {
+	r.mu.Lock()
+	sorted := make([]float64, len(r.samples))
+	copy(sorted, r.samples)
+	r.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return 0
+	}
+	sort.Float64s(sorted)
+
+	var (
+		pos  = math.Max(0, math.Min(1, q)) * float64(len(sorted)-1)
+		lo   = int(math.Floor(pos))
+		hi   = int(math.Ceil(pos))
+		frac = pos - float64(lo)
+	)
+	return sorted[lo] + (sorted[hi]-sorted[lo])*frac
+}
+
+// Reset discards all observed values and resets the recorder's epoch.
+func (r *DistRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = r.samples[:0]
+	r.count = 0
+	r.sum = 0
+	r.epoch = r.clock.Nanotime()
+}
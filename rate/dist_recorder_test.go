@@ -0,0 +1,82 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+)
+
+func TestDistRecorder(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewDistRecorder(rate.Options{Clock: clk})
+	)
+
+	// An empty recorder reports zeroes.
+	require.Zero(t, recorder.Count())
+	require.Zero(t, recorder.Mean())
+	require.Zero(t, recorder.Quantile(0.5))
+
+	for i := 100; i > 0; i-- {
+		recorder.Observe(float64(i))
+	}
+	clk.Add(time.Second)
+
+	require.EqualValues(t, 100, recorder.Count())
+	require.Equal(t, time.Second, recorder.Elapsed())
+	require.InDelta(t, 50.5, recorder.Mean(), 1e-9)
+	require.InDelta(t, 1, recorder.Quantile(0), 1e-9)
+	require.InDelta(t, 50.5, recorder.Quantile(0.5), 1e-9)
+	require.InDelta(t, 99.01, recorder.Quantile(0.99), 1e-9)
+	require.InDelta(t, 100, recorder.Quantile(1), 1e-9)
+
+	// Out-of-range quantiles are clamped.
+	require.InDelta(t, 1, recorder.Quantile(-1), 1e-9)
+	require.InDelta(t, 100, recorder.Quantile(2), 1e-9)
+
+	recorder.Reset()
+	require.Zero(t, recorder.Count())
+	require.Zero(t, recorder.Elapsed())
+	require.Zero(t, recorder.Mean())
+	require.Zero(t, recorder.Quantile(0.5))
+}
+
+func TestDistRecorder_Reservoir(t *testing.T) {
+	recorder := rate.NewDistRecorder()
+
+	// Observe far more values than the reservoir can hold; the mean remains
+	// exact and the estimated quantiles should remain close.
+	const n = 100_000
+	for i := 0; i < n; i++ {
+		recorder.Observe(float64(i % 1_000))
+	}
+
+	require.EqualValues(t, n, recorder.Count())
+	require.InDelta(t, 499.5, recorder.Mean(), 1e-9)
+	require.InDelta(t, 0, recorder.Quantile(0), 50)
+	require.InDelta(t, 500, recorder.Quantile(0.5), 100)
+	require.InDelta(t, 999, recorder.Quantile(1), 50)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.mway.dev/chrono/clock"
+)
+
+// A Limiter limits the rate at which events may occur using a token bucket:
+// the bucket holds at most burst tokens, is refilled continuously at a fixed
+// rate, and each event consumes one token. Refills are computed from the
+// limiter's clock, which makes a Limiter deterministic under a
+// [clock.FakeClock].
+type Limiter struct {
+	clock  clock.Clock
+	perNs  float64
+	burst  float64
+	tokens float64
+	last   int64
+	mu     sync.Mutex
+}
+
+// NewLimiter creates a new [Limiter] that allows perSecond events per second,
+// with bursts of up to burst events, configured by the given options. The
+// limiter starts with a full bucket. If burst < 1, a burst of 1 is used. If
+// perSecond <= 0, the bucket is never refilled.
+func NewLimiter(perSecond float64, burst int, opts ...Option) *Limiter {
+	options := DefaultOptions().With(opts...)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		clock:  options.Clock,
+		perNs:  math.Max(perSecond, 0) / float64(time.Second),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   options.Clock.Nanotime(),
+	}
+}
+
+// Allow reports whether an event may occur now, consuming a token if so.
+func (l *Limiter) Allow() bool {
+	_, ok := l.take()
+	return ok
+}
+
+// Tokens returns the number of tokens currently available.
+func (l *Limiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refillNosync()
+	return l.tokens
+}
+
+// Wait blocks until an event may occur, consuming a token and returning nil,
+// or until ctx expires, in which case it returns ctx.Err(). The time waited is
+// measured by the limiter's clock. If the limiter is never refilled (i.e. it
+// was created with perSecond <= 0) and has no tokens, Wait blocks until ctx
+// expires.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		wait, ok := l.take()
+		if ok {
+			return nil
+		}
+
+		if err := l.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *Limiter) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return clock.Wait(ctx, l.clock, d)
+}
+
+// take consumes a token if one is available. Otherwise, it returns the time
+// until the next token is available, or 0 if the limiter is never refilled.
+func (l *Limiter) take() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refillNosync()
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	if l.perNs == 0 {
+		return 0, false
+	}
+	return time.Duration(math.Ceil((1 - l.tokens) / l.perNs)), false
+}
+
+func (l *Limiter) refillNosync() {
+	now := l.clock.Nanotime()
+	if elapsed := now - l.last; elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+float64(elapsed)*l.perNs)
+	}
+	l.last = now
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		limiter = rate.NewLimiter(10, 3, rate.Options{Clock: clk})
+	)
+
+	// The limiter starts with a full bucket.
+	for i := 0; i < 3; i++ {
+		require.True(t, limiter.Allow())
+	}
+	require.False(t, limiter.Allow())
+
+	// Tokens refill at the configured rate.
+	clk.Add(50 * time.Millisecond)
+	require.InDelta(t, 0.5, limiter.Tokens(), 1e-9)
+	require.False(t, limiter.Allow())
+	clk.Add(50 * time.Millisecond)
+	require.True(t, limiter.Allow())
+	require.False(t, limiter.Allow())
+
+	// The bucket never holds more than the burst.
+	clk.Add(time.Minute)
+	require.InDelta(t, 3, limiter.Tokens(), 1e-9)
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	var (
+		clk     = clock.NewFakeClock()
+		limiter = rate.NewLimiter(1, 1, rate.Options{Clock: clk})
+		errs    = make(chan error)
+	)
+
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	go func() {
+		errs <- limiter.Wait(context.Background())
+	}()
+
+	// Wait blocks until the next token is available.
+	clk.BlockUntil(1)
+	clk.Add(999 * time.Millisecond)
+	select {
+	case err := <-errs:
+		require.FailNow(t, "wait returned early", "err: %v", err)
+	default:
+	}
+
+	clk.Add(time.Millisecond)
+	select {
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for limiter")
+	}
+}
+
+func TestLimiter_Wait_Canceled(t *testing.T) {
+	var (
+		clk         = clock.NewFakeClock()
+		limiter     = rate.NewLimiter(0, 0, rate.Options{Clock: clk})
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+
+	// A limiter that never refills still starts with a single token.
+	require.True(t, limiter.Allow())
+	clk.Add(time.Hour)
+	require.False(t, limiter.Allow())
+
+	cancel()
+	require.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"sync"
+)
+
+// A MultiRecorder records added counts for many keys, and reports the rate of
+// each key's total count over its elapsed time. Each key is tracked by its own
+// [Recorder], which is created the first time that a count is added for the
+// key; all recorders share the same clock and options.
+type MultiRecorder struct {
+	options   Options
+	recorders map[string]*Recorder
+	mu        sync.RWMutex
+}
+
+// NewMultiRecorder creates a new [MultiRecorder] configured by the given
+// options.
+func NewMultiRecorder(opts ...Option) *MultiRecorder {
+	return &MultiRecorder{
+		options:   DefaultOptions().With(opts...),
+		recorders: make(map[string]*Recorder),
+	}
+}
+
+// Add adds n to the running count for key. If key has not been seen before, its
+// recorder is created and its epoch begins now.
+func (m *MultiRecorder) Add(key string, n int) {
+	m.recorder(key).Add(n)
+}
+
+// Rate returns a [Rate] that represents the running count and time elapsed for
+// key. If key has not been seen before, a zero Rate is returned.
+func (m *MultiRecorder) Rate(key string) Rate {
+	if r, ok := m.lookup(key); ok {
+		return r.Rate()
+	}
+	return Rate{}
+}
+
+// Rates returns the current [Rate] of every key that has been seen.
+func (m *MultiRecorder) Rates() map[string]Rate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rates := make(map[string]Rate, len(m.recorders))
+	for key, r := range m.recorders {
+		rates[key] = r.Rate()
+	}
+
+	return rates
+}
+
+// Reset returns the current [Rate] for key and resets its running count and
+// epoch. If key has not been seen before, a zero Rate is returned.
+func (m *MultiRecorder) Reset(key string) Rate {
+	if r, ok := m.lookup(key); ok {
+		return r.Reset()
+	}
+	return Rate{}
+}
+
+func (m *MultiRecorder) lookup(key string) (*Recorder, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, ok := m.recorders[key]
+	return r, ok
+}
+
+func (m *MultiRecorder) recorder(key string) *Recorder {
+	if r, ok := m.lookup(key); ok {
+		return r
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Another caller may have created the recorder while the lock was not
+	// held.
+	if r, ok := m.recorders[key]; ok {
+		return r
+	}
+
+	r := NewRecorder(m.options)
+	m.recorders[key] = r
+	return r
+}
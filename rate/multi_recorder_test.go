@@ -0,0 +1,102 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+)
+
+func TestMultiRecorder(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewMultiRecorder(rate.Options{Clock: clk})
+	)
+
+	// Unseen keys report a zero rate.
+	require.Empty(t, recorder.Rates())
+	require.Equal(t, rate.Rate{}, recorder.Rate("a"))
+	require.Equal(t, rate.Rate{}, recorder.Reset("a"))
+
+	recorder.Add("a", 1_000)
+	recorder.Add("b", 2_000)
+	clk.Add(time.Second)
+
+	require.EqualValues(t, 1_000, recorder.Rate("a").Per(time.Second))
+	require.EqualValues(t, 2_000, recorder.Rate("b").Per(time.Second))
+
+	// Keys are tracked independently: "c" starts its epoch a second later
+	// than the others.
+	recorder.Add("c", 3_000)
+	clk.Add(time.Second)
+
+	rates := recorder.Rates()
+	require.Len(t, rates, 3)
+	require.EqualValues(t, 500, rates["a"].Per(time.Second))
+	require.EqualValues(t, 1_000, rates["b"].Per(time.Second))
+	require.EqualValues(t, 3_000, rates["c"].Per(time.Second))
+
+	// Resetting one key does not affect the others.
+	require.EqualValues(t, 500, recorder.Reset("a").Per(time.Second))
+	recorder.Add("a", 100)
+	clk.Add(time.Second)
+	require.EqualValues(t, 100, recorder.Rate("a").Per(time.Second))
+	require.InDelta(t, 2_000, recorder.Rate("b").Per(3*time.Second), 1e-9)
+}
+
+func TestMultiRecorder_Concurrent(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewMultiRecorder(rate.Options{Clock: clk})
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				recorder.Add(strconv.Itoa(j%10), 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	clk.Add(time.Second)
+
+	rates := recorder.Rates()
+	require.Len(t, rates, 10)
+	for key, r := range rates {
+		require.EqualValues(t, 800, r.Per(time.Second), key)
+	}
+}
+
+func TestMultiRecorder_DefaultClock(t *testing.T) {
+	recorder := rate.NewMultiRecorder()
+	recorder.Add("a", 1_000_000)
+	require.True(t, recorder.Reset("a").Per(time.Nanosecond) > 0.0)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"time"
+
+	"go.mway.dev/chrono/clock"
+)
+
+// Options configure rate types.
+type Options struct {
+	// Clock configures the [clock.Clock] used to measure time.
+	Clock clock.Clock
+	// PollInterval configures how often blocking operations, such as
+	// [Recorder.WaitForRate], check the current rate.
+	PollInterval time.Duration
+	// HalfLife configures recorders to decay their running counts over time.
+	// See [WithDecay] for more information.
+	HalfLife time.Duration
+	// InitialCount configures the running count that a [Recorder] starts
+	// with. See [WithInitialCount] for more information.
+	InitialCount int64
+	// InitialCountFloat configures a fractional running count that a
+	// [Recorder] starts with. See [WithInitialCountFloat] for more
+	// information.
+	InitialCountFloat float64
+	// Epoch configures the time, per the configured clock, from which a
+	// [Recorder] measures elapsed time, if non-nil. See [WithEpoch] for more
+	// information.
+	Epoch *int64
+}
+
+// DefaultOptions returns a new [Options] with sane defaults.
+func DefaultOptions() Options {
+	return Options{
+		Clock:        clock.Monotonic(),
+		PollInterval: 100 * time.Millisecond,
+	}
+}
+
+// With returns a new [Options] with opts merged on top of o.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(opts *Options) {
+	if o.Clock != nil {
+		opts.Clock = o.Clock
+	}
+
+	if o.PollInterval > 0 {
+		opts.PollInterval = o.PollInterval
+	}
+
+	if o.HalfLife > 0 {
+		opts.HalfLife = o.HalfLife
+	}
+
+	if o.InitialCount != 0 {
+		opts.InitialCount = o.InitialCount
+	}
+
+	if o.InitialCountFloat != 0 {
+		opts.InitialCountFloat = o.InitialCountFloat
+	}
+
+	if o.Epoch != nil {
+		opts.Epoch = o.Epoch
+	}
+}
+
+// An Option configures rate types.
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithClock returns an [Option] that configures rate types to use the given
+// [clock.Clock] for measuring time. If clk is nil, the option has no effect.
+func WithClock(clk clock.Clock) Option {
+	return optionFunc(func(o *Options) {
+		if clk != nil {
+			o.Clock = clk
+		}
+	})
+}
+
+// WithPollInterval returns an [Option] that configures how often blocking
+// operations check the current rate. If d <= 0, the option has no effect.
+func WithPollInterval(d time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		if d > 0 {
+			o.PollInterval = d
+		}
+	})
+}
+
+// WithDecay returns an [Option] that configures a [Recorder] to weight recent
+// counts more heavily than older ones: the running count decays toward zero,
+// halving every halfLife that elapses without a call to [Recorder.Add]. The
+// elapsed time used to compute a [Rate] is unaffected. An [SLORecorder] is
+// similarly configured to weight recent values more heavily when computing
+// [SLORecorder.Compliance]. If halfLife <= 0, the option has no effect.
+func WithDecay(halfLife time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		if halfLife > 0 {
+			o.HalfLife = halfLife
+		}
+	})
+}
+
+// WithInitialCount returns an [Option] that configures a [Recorder] to start
+// with a running count of n rather than zero, e.g. to resume from state that
+// was persisted via [Recorder.Snapshot]. The initial count only applies to the
+// recorder's first epoch; it is discarded by [Recorder.Reset]. If n is 0, the
+// option has no effect.
+func WithInitialCount(n int64) Option {
+	return optionFunc(func(o *Options) {
+		if n != 0 {
+			o.InitialCount = n
+		}
+	})
+}
+
+// WithInitialCountFloat is like [WithInitialCount], but the initial count may
+// include fractional counts, e.g. to resume from state that was persisted via
+// [Recorder.SnapshotFloat]. If both options are given, the initial counts are
+// summed. If f is 0, the option has no effect.
+func WithInitialCountFloat(f float64) Option {
+	return optionFunc(func(o *Options) {
+		if f != 0 {
+			o.InitialCountFloat = f
+		}
+	})
+}
+
+// WithEpoch returns an [Option] that configures a [Recorder] to measure
+// elapsed time from ns, as measured by the recorder's clock, rather than from
+// the time at which the recorder was created, e.g. to resume from state that
+// was persisted via [Recorder.Snapshot]. An epoch that is later than the
+// clock's current time would yield negative elapsed times, so such an epoch is
+// replaced by the clock's current time. Any ns is valid, including 0, which is
+// e.g. the initial time of a [clock.FakeClock].
+func WithEpoch(ns int64) Option {
+	return optionFunc(func(o *Options) {
+		o.Epoch = &ns
+	})
+}
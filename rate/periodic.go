@@ -0,0 +1,53 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mway.dev/chrono/periodic"
+)
+
+// StartPeriodicReset resets rec every interval, as measured by rec's clock,
+// and passes each resulting [Rate] to fn, until ctx expires or the returned
+// handle is stopped. fn is called synchronously; a slow fn delays subsequent
+// resets. If interval is not greater than zero, StartPeriodicReset will panic.
+func StartPeriodicReset(
+	ctx context.Context,
+	rec *Recorder,
+	interval time.Duration,
+	fn func(Rate),
+) *periodic.Handle {
+	if interval <= 0 {
+		panic(errors.New("non-positive interval for StartPeriodicReset"))
+	}
+
+	return periodic.StartWithContext(
+		ctx,
+		interval,
+		func(context.Context) {
+			fn(rec.Reset())
+		},
+		periodic.WithClock(rec.clock),
+	)
+}
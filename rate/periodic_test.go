@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+)
+
+func TestStartPeriodicReset(t *testing.T) {
+	var (
+		clk         = clock.NewFakeClock()
+		recorder    = rate.NewRecorderWithClock(clk)
+		rates       = make(chan rate.Rate, 1)
+		ctx, cancel = context.WithCancel(context.Background())
+		handle      = rate.StartPeriodicReset(
+			ctx,
+			recorder,
+			time.Second,
+			func(r rate.Rate) {
+				rates <- r
+			},
+		)
+	)
+	defer handle.Stop()
+
+	for i := 1; i <= 3; i++ {
+		recorder.Add(i * 1_000)
+		clk.Add(time.Second)
+
+		select {
+		case r := <-rates:
+			require.EqualValues(t, i*1_000, r.Per(time.Second))
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for rate")
+		}
+	}
+
+	cancel()
+	select {
+	case <-handle.Done():
+	case <-time.After(time.Second):
+		require.FailNow(t, "handle did not stop")
+	}
+
+	// The recorder is no longer reset once stopped.
+	recorder.Add(1_000)
+	clk.Add(time.Second)
+	require.EqualValues(t, 1_000, recorder.Rate().Per(time.Second))
+}
+
+func TestStartPeriodicReset_NonPositiveInterval(t *testing.T) {
+	recorder := rate.NewRecorderWithClock(clock.NewFakeClock())
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		require.Panics(t, func() {
+			rate.StartPeriodicReset(
+				context.Background(),
+				recorder,
+				interval,
+				func(rate.Rate) {},
+			)
+		})
+	}
+}
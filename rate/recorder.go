@@ -21,6 +21,10 @@
 package rate
 
 import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
 	"time"
 
 	"go.mway.dev/chrono/clock"
@@ -31,58 +35,366 @@ import (
 // the elapsed time.
 type Recorder struct {
 	clock clock.Clock
+	decay *decayingCount
 	count atomic.Int64
+	frac  atomic.Float64
 	epoch atomic.Int64
+	poll  time.Duration
 }
 
-// NewRecorder creates a new [Recorder] that uses the system's monotonic clock.
-func NewRecorder() *Recorder {
-	return NewRecorderWithClock(clock.NewMonotonicClock())
-}
-
-// NewRecorderWithClock returns a new [Recorder] that uses the given clock.
-func NewRecorderWithClock(clk clock.Clock) *Recorder {
+// NewRecorder creates a new [Recorder] configured by the given options. Unless
+// configured otherwise via [WithClock], the recorder uses the system's
+// monotonic clock. A recorder's state can be restored from a previous
+// [Recorder.Snapshot] via [WithInitialCount] and [WithEpoch].
+func NewRecorder(opts ...Option) *Recorder {
+	options := DefaultOptions().With(opts...)
 	r := &Recorder{
-		clock: clk,
+		clock: options.Clock,
+		poll:  options.PollInterval,
+	}
+	if options.HalfLife > 0 {
+		r.decay = &decayingCount{
+			halfLife: float64(options.HalfLife),
+		}
 	}
 	r.Reset()
+	r.restore(options.InitialCount, options.InitialCountFloat, options.Epoch)
 	return r
 }
 
-// Add adds n to the running count.
+// NewRecorderWithClock returns a new [Recorder] that uses the given clock and
+// is configured by the given options. The given clock takes precedence over
+// any clock provided via opts.
+//
+// Deprecated: Use [NewRecorder] with [WithClock] instead.
+func NewRecorderWithClock(clk clock.Clock, opts ...Option) *Recorder {
+	// Copy opts so that the caller's backing array is never written to.
+	return NewRecorder(append(append([]Option(nil), opts...), WithClock(clk))...)
+}
+
+// Add adds n to the running count. If the recorder was created with
+// [WithDecay], the running count is first decayed according to the time
+// elapsed since the previous call to Add.
 func (r *Recorder) Add(n int) {
+	if r.decay != nil {
+		r.decay.add(r.clock.Nanotime(), float64(n))
+		return
+	}
 	r.count.Add(int64(n))
 }
 
+// AddFloat adds f to the running count, e.g. for weighted events or partial
+// quantities. Fractional counts are accumulated separately from the integer
+// counts added via [Recorder.Add], as a float64: integer counts remain exact,
+// but the fractional accumulator is only exact for integers up to 2^53, and
+// summing many fractions may accumulate rounding error. Rates include both
+// accumulators, although [Recorder.Snapshot] only reports integer counts (see
+// [Recorder.SnapshotFloat]). If the recorder was created with [WithDecay], f
+// is added to the decaying count like n is by Add, and rates include the
+// decayed count's fraction.
+func (r *Recorder) AddFloat(f float64) {
+	if r.decay != nil {
+		r.decay.add(r.clock.Nanotime(), f)
+		return
+	}
+	r.frac.Add(f)
+}
+
 // Rate returns a [Rate] that represents the running count and time elapsed
 // since the recorder's clock started.
 func (r *Recorder) Rate() Rate {
+	var (
+		epoch       = r.epoch.Load()
+		count, frac = r.loadCount()
+	)
+	return Rate{
+		count:   count,
+		frac:    frac,
+		elapsed: r.clock.SinceNanotime(epoch),
+	}
+}
+
+// RateSince returns a [Rate] that represents the running count over the time
+// elapsed since ns, as measured by the recorder's clock, rather than since the
+// recorder's epoch. This is useful for callers that track their own epochs;
+// note that the count still includes everything added since the recorder's
+// epoch, regardless of ns.
+func (r *Recorder) RateSince(ns int64) Rate {
+	count, frac := r.loadCount()
 	return Rate{
-		count:   r.count.Load(),
-		elapsed: r.clock.SinceNanotime(r.epoch.Load()),
+		count:   count,
+		frac:    frac,
+		elapsed: r.clock.SinceNanotime(ns),
 	}
 }
 
+// RateOver returns a [Rate] like [Recorder.Rate], but limited to at most the
+// given window: if more than window has elapsed since the recorder's epoch,
+// counts are assumed to have arrived uniformly over the elapsed time, and the
+// count is scaled down proportionally to the portion that falls within the
+// window. The scaled count keeps any fractional part (see [Rate.Scale]), so a
+// small count over a long elapsed time does not collapse to zero. If window
+// <= 0, RateOver is equivalent to [Recorder.Rate].
+func (r *Recorder) RateOver(window time.Duration) Rate {
+	rate := r.Rate()
+	if window <= 0 || rate.elapsed <= window {
+		return rate
+	}
+
+	scaled := rate.Scale(float64(window) / float64(rate.elapsed))
+	scaled.elapsed = window
+	return scaled
+}
+
 // Reset returns the current [Rate] and resets the recorder's running count and
-// epoch.
+// epoch. If the recorder was created with [WithDecay], the returned Rate
+// reflects the decayed count, and any decayed count that remains is discarded
+// rather than carried over into the new epoch.
 func (r *Recorder) Reset() Rate {
 	var (
-		now     = r.clock.Nanotime()
-		elapsed = time.Duration(now - r.epoch.Swap(now))
+		now         = r.clock.Nanotime()
+		elapsed     = time.Duration(now - r.epoch.Swap(now))
+		count, frac = r.swapCount(now)
 	)
 	return Rate{
-		count:   r.count.Swap(0),
+		count:   count,
+		frac:    frac,
 		elapsed: elapsed,
 	}
 }
 
+// WaitForRate blocks until the recorder's current rate per the given period
+// meets or exceeds target, in which case it returns nil, or until ctx expires,
+// in which case it returns ctx.Err(). The rate is checked immediately and then
+// at the configured poll interval (see [WithPollInterval]), as measured by the
+// recorder's clock.
+func (r *Recorder) WaitForRate(
+	ctx context.Context,
+	target float64,
+	per time.Duration,
+) error {
+	if r.Rate().Per(per) >= target {
+		return nil
+	}
+
+	ticker := r.clock.NewTicker(r.poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if r.Rate().Per(per) >= target {
+				return nil
+			}
+		}
+	}
+}
+
+// Snapshot returns the recorder's running count, the time elapsed since its
+// epoch, and the epoch itself, without modifying the recorder. The count and
+// epoch are each read exactly once, but not atomically with respect to each
+// other: a concurrent call to [Recorder.Add] or [Recorder.Reset] may be
+// partially reflected in the result. The count does not include fractional
+// counts added via [Recorder.AddFloat]; use [Recorder.SnapshotFloat] to include
+// them.
+func (r *Recorder) Snapshot() (count int64, elapsed time.Duration, epoch int64) {
+	count, _ = r.loadCount()
+	epoch = r.epoch.Load()
+	elapsed = r.clock.SinceNanotime(epoch)
+	return count, elapsed, epoch
+}
+
+// SnapshotFloat is like [Recorder.Snapshot], but the count includes fractional
+// counts added via [Recorder.AddFloat]. Its state can be restored via
+// [WithInitialCountFloat] and [WithEpoch].
+func (r *Recorder) SnapshotFloat() (count float64, elapsed time.Duration, epoch int64) {
+	whole, frac := r.loadCount()
+	epoch = r.epoch.Load()
+	elapsed = r.clock.SinceNanotime(epoch)
+	return float64(whole) + frac, elapsed, epoch
+}
+
+func (r *Recorder) restore(count int64, f float64, epoch *int64) {
+	now := r.clock.Nanotime()
+
+	if count != 0 || f != 0 {
+		if r.decay != nil {
+			r.decay.seed(now, float64(count)+f)
+		} else {
+			whole := math.Trunc(f)
+			r.count.Store(count + int64(whole))
+			r.frac.Store(f - whole)
+		}
+	}
+
+	if epoch != nil {
+		r.epoch.Store(min(*epoch, now))
+	}
+}
+
+// loadCount returns the recorder's integer and fractional counts. A decayed
+// count is split into its nearest integer and the remainder.
+func (r *Recorder) loadCount() (int64, float64) {
+	if r.decay != nil {
+		return splitCount(r.decay.load(r.clock.Nanotime()))
+	}
+	return r.count.Load(), r.frac.Load()
+}
+
+func (r *Recorder) swapCount(now int64) (int64, float64) {
+	if r.decay != nil {
+		return splitCount(r.decay.swap(now))
+	}
+	return r.count.Swap(0), r.frac.Swap(0)
+}
+
+func splitCount(value float64) (int64, float64) {
+	whole := math.Round(value)
+	return int64(whole), value - whole
+}
+
+// A decayingCount is a count that halves every halfLife nanoseconds since it
+// was last added to.
+type decayingCount struct {
+	halfLife float64
+	value    float64
+	last     int64
+	mu       sync.Mutex
+}
+
+func (c *decayingCount) add(now int64, n float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value = c.valueAtNosync(now) + n
+	c.last = now
+}
+
+func (c *decayingCount) seed(now int64, n float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value = n
+	c.last = now
+}
+
+func (c *decayingCount) load(now int64) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.valueAtNosync(now)
+}
+
+func (c *decayingCount) swap(now int64) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value := c.valueAtNosync(now)
+	c.value = 0
+	c.last = now
+	return value
+}
+
+func (c *decayingCount) valueAtNosync(now int64) float64 {
+	return c.value * math.Exp2(-float64(now-c.last)/c.halfLife)
+}
+
 // A Rate is a count over a period of time.
 type Rate struct {
 	count   int64
+	frac    float64 // see Recorder.AddFloat
 	elapsed time.Duration
 }
 
-// Per returns the rate's count over the given period of time.
+// IsZero reports whether the rate's count is zero, e.g. because nothing has
+// been added to the [Recorder] that produced it. A zero Rate's Per is always 0.
+func (r Rate) IsZero() bool {
+	return r.count == 0 && r.frac == 0
+}
+
+// Per returns the rate's count over the given period of time. If no time has
+// elapsed, or the elapsed time is negative (e.g. because a wall clock moved
+// backward), the rate is undefined and Per returns 0.
 func (r Rate) Per(d time.Duration) float64 {
-	return (float64(r.count) / float64(r.elapsed)) * float64(d)
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return (r.total() / float64(r.elapsed)) * float64(d)
+}
+
+// Add returns a new [Rate] that combines r and other, e.g. for aggregating rates
+// measured by multiple shards. The combined count is the sum of both counts,
+// and the combined elapsed time is the longer of the two elapsed times: the
+// shards are assumed to have been measured over overlapping windows, so the
+// combined rate is the total count over the widest window. If both rates were
+// measured over the same window, Per of the result is the sum of each rate's
+// Per.
+func (r Rate) Add(other Rate) Rate {
+	elapsed := r.elapsed
+	if other.elapsed > elapsed {
+		elapsed = other.elapsed
+	}
+
+	return Rate{
+		count:   r.count + other.count,
+		frac:    r.frac + other.frac,
+		elapsed: elapsed,
+	}
+}
+
+// Scale returns a new [Rate] with r's count, including any fractional count
+// (see [Recorder.AddFloat]), multiplied by factor. The scaled count is not
+// rounded: any fractional part is kept, so that scaling a small count down
+// does not collapse it to zero. The elapsed time is unchanged.
+func (r Rate) Scale(factor float64) Rate {
+	count, frac := splitCount(r.total() * factor)
+	return Rate{
+		count:   count,
+		frac:    frac,
+		elapsed: r.elapsed,
+	}
+}
+
+// total returns the rate's integer and fractional counts combined.
+func (r Rate) total() float64 {
+	return float64(r.count) + r.frac
+}
+
+// MarshalJSON encodes r as a JSON object with its count, its elapsed time in
+// nanoseconds, and, for convenience, its rate per second, e.g.
+// {"count":10,"elapsed_ns":2000000000,"per_second":5}. If no time has elapsed,
+// per_second is 0; see [Rate.Per]. Any fractional count (see
+// [Recorder.AddFloat]) is encoded separately as count_fraction.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rateJSON{
+		Count:     r.count,
+		Fraction:  r.frac,
+		ElapsedNS: int64(r.elapsed),
+		PerSecond: r.Per(time.Second),
+	})
+}
+
+// UnmarshalJSON decodes a JSON object produced by [Rate.MarshalJSON] into r.
+// Only the counts and elapsed time are used; per_second is derived from them,
+// and so is ignored.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var v rateJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	r.count = v.Count
+	r.frac = v.Fraction
+	r.elapsed = time.Duration(v.ElapsedNS)
+	return nil
+}
+
+// rateJSON is the JSON representation of a [Rate].
+type rateJSON struct {
+	Count     int64   `json:"count"`
+	Fraction  float64 `json:"count_fraction,omitempty"`
+	ElapsedNS int64   `json:"elapsed_ns"`
+	PerSecond float64 `json:"per_second"`
 }
@@ -21,6 +21,9 @@
 package rate_test
 
 import (
+	"context"
+	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
@@ -70,6 +73,425 @@ func TestRecorder(t *testing.T) {
 	require.EqualValues(t, 1_000_000, rate.Per(time.Second))
 }
 
+func TestRecorder_WithClock(t *testing.T) {
+	var (
+		clk   = clock.NewFakeClock()
+		other = clock.NewFakeClock()
+	)
+
+	// A nil clock is ignored in favor of the default.
+	recorder := rate.NewRecorder(rate.WithClock(clk), rate.WithClock(nil))
+	recorder.Add(10)
+	clk.Add(time.Second)
+	require.EqualValues(t, 10, recorder.Rate().Per(time.Second))
+
+	// The deprecated constructor's clock takes precedence over options.
+	//nolint:staticcheck
+	recorder = rate.NewRecorderWithClock(clk, rate.WithClock(other))
+	recorder.Add(10)
+	clk.Add(time.Second)
+	require.EqualValues(t, 10, recorder.Rate().Per(time.Second))
+	// The caller's options are not modified.
+	opts := make([]rate.Option, 1, 2)
+	opts[0] = rate.WithClock(other)
+	//nolint:staticcheck
+	rate.NewRecorderWithClock(clk, opts...)
+	require.Nil(t, opts[:2][1])
+}
+
+func TestRecorder_WithDecay(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk, rate.WithDecay(time.Second))
+	)
+
+	clk.Add(time.Second)
+	recorder.Add(1024)
+	require.EqualValues(t, 1024, recorder.Rate().Per(time.Second))
+
+	// The count should halve every second, while the elapsed time continues
+	// to accumulate from the epoch.
+	for i, want := range []float64{512, 256, 128, 64} {
+		clk.Add(time.Second)
+		require.InDelta(t, want/float64(i+2), recorder.Rate().Per(time.Second), 1e-9)
+	}
+
+	// Partial half-lives decay proportionally.
+	clk.Add(500 * time.Millisecond)
+	count, _, _ := recorder.Snapshot()
+	require.EqualValues(t, 45, count) // 64 * 2^-0.5
+
+	// New counts are added to the decayed count.
+	recorder.Add(19)
+	count, _, _ = recorder.Snapshot()
+	require.EqualValues(t, 64, count)
+
+	// Reset returns the decayed count, including its fraction, and discards
+	// it.
+	clk.Add(time.Second)
+	rate := recorder.Reset()
+	require.InDelta(t, (64/math.Sqrt2+19)/2/6.5, rate.Per(time.Second), 1e-9)
+	clk.Add(time.Second)
+	require.Zero(t, recorder.Rate().Per(time.Second))
+
+	recorder.Add(100)
+	clk.Add(time.Second)
+	require.EqualValues(t, 25, recorder.Rate().Per(time.Second))
+}
+
+func TestRecorder_AddFloat(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(rate.WithClock(clk))
+	)
+
+	clk.Add(time.Second)
+	recorder.AddFloat(0.25)
+	require.False(t, recorder.Rate().IsZero())
+	require.InDelta(t, 0.25, recorder.Rate().Per(time.Second), 1e-9)
+
+	// Integer and fractional counts combine.
+	recorder.Add(2)
+	recorder.AddFloat(0.5)
+	require.InDelta(t, 2.75, recorder.Rate().Per(time.Second), 1e-9)
+	require.InDelta(t, 5.5, recorder.Rate().Per(2*time.Second), 1e-9)
+
+	// Scaling keeps the combined count.
+	require.InDelta(t, 2.75, recorder.Rate().Scale(1).Per(time.Second), 1e-9)
+
+	// Combined rates include both counts.
+	other := rate.NewRecorder(rate.WithClock(clk))
+	other.AddFloat(1.25)
+	require.InDelta(t, 4, recorder.Rate().Add(other.Rate()).Per(time.Second), 1e-9)
+
+	// Snapshots only report integer counts.
+	count, _, _ := recorder.Snapshot()
+	require.EqualValues(t, 2, count)
+
+	// Resetting discards fractional counts.
+	require.InDelta(t, 2.75, recorder.Reset().Per(time.Second), 1e-9)
+	clk.Add(time.Second)
+	require.True(t, recorder.Rate().IsZero())
+}
+
+func TestRecorder_AddFloat_WithDecay(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(
+			rate.WithClock(clk),
+			rate.WithDecay(time.Second),
+		)
+	)
+
+	// Fractional counts decay with integer counts. Snapshots round the
+	// decayed count, while rates keep its fraction.
+	recorder.AddFloat(1.5)
+	recorder.Add(2)
+	clk.Add(time.Second)
+	count, _, _ := recorder.Snapshot()
+	require.EqualValues(t, 2, count)
+	require.InDelta(t, 1.75, recorder.Rate().Per(time.Second), 1e-9)
+
+	fcount, _, _ := recorder.SnapshotFloat()
+	require.InDelta(t, 1.75, fcount, 1e-9)
+
+	// Counts below one are not lost.
+	recorder.Reset()
+	recorder.AddFloat(0.25)
+	clk.Add(time.Second)
+	require.False(t, recorder.Rate().IsZero())
+	require.InDelta(t, 0.125, recorder.Rate().Per(time.Second), 1e-9)
+}
+
+func TestRecorder_Restore_Float(t *testing.T) {
+	for name, opts := range map[string][]rate.Option{
+		"default": nil,
+		"decay":   {rate.WithDecay(time.Hour)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			clk := clock.NewFakeClock()
+			clk.SetNanotime(int64(time.Minute))
+
+			recorder := rate.NewRecorder(append(opts, rate.WithClock(clk))...)
+			clk.Add(10 * time.Second)
+			recorder.Add(2)
+			recorder.AddFloat(0.75)
+			count, _, epoch := recorder.SnapshotFloat()
+			require.InDelta(t, 2.75, count, 1e-6)
+
+			// Fractional counts survive a restore.
+			restored := rate.NewRecorder(append(
+				opts,
+				rate.WithClock(clk),
+				rate.WithInitialCountFloat(count),
+				rate.WithEpoch(epoch),
+			)...)
+			got, _, _ := restored.SnapshotFloat()
+			require.InDelta(t, count, got, 1e-6)
+			require.InDelta(
+				t,
+				recorder.Rate().Per(time.Second),
+				restored.Rate().Per(time.Second),
+				1e-6,
+			)
+
+			// Integer and fractional initial counts are summed.
+			restored = rate.NewRecorder(append(
+				opts,
+				rate.WithClock(clk),
+				rate.WithInitialCount(1),
+				rate.WithInitialCountFloat(1.5),
+			)...)
+			got, _, _ = restored.SnapshotFloat()
+			require.InDelta(t, 2.5, got, 1e-6)
+		})
+	}
+}
+
+func TestRecorder_WithDecay_Disabled(t *testing.T) {
+	for _, d := range []time.Duration{0, -1} {
+		var (
+			clk      = clock.NewFakeClock()
+			recorder = rate.NewRecorderWithClock(clk, rate.WithDecay(d))
+		)
+
+		recorder.Add(100)
+		clk.Add(time.Hour)
+		require.InDelta(t, 100, recorder.Rate().Per(time.Hour), 1e-9)
+	}
+}
+
+func TestRecorder_RateSince(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	recorder.Add(1_000)
+	clk.Add(9 * time.Second)
+	since := clk.Nanotime()
+	clk.Add(time.Second)
+
+	require.EqualValues(t, 100, recorder.Rate().Per(time.Second))
+	require.EqualValues(t, 1_000, recorder.RateSince(since).Per(time.Second))
+}
+
+func TestRecorder_RateOver(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	recorder.Add(1_000)
+	clk.Add(10 * time.Second)
+
+	// Windows at least as large as the elapsed time are unaffected.
+	require.Equal(t, recorder.Rate(), recorder.RateOver(time.Minute))
+	require.Equal(t, recorder.Rate(), recorder.RateOver(10*time.Second))
+	require.Equal(t, recorder.Rate(), recorder.RateOver(0))
+
+	// Smaller windows scale the count down, leaving the rate unchanged.
+	windowed := recorder.RateOver(time.Second)
+	require.NotEqual(t, recorder.Rate(), windowed)
+	require.EqualValues(t, 100, windowed.Per(time.Second))
+
+	// The windowed rate only counts the 100 that arrived within the window:
+	// combined with the full rate, the total count is 1,100 over 10s.
+	require.EqualValues(t, 110, windowed.Add(recorder.Rate()).Per(time.Second))
+
+	// A count smaller than the number of windows in the elapsed time is not
+	// rounded away: 1 over 10s is 0.1 within a 1s window.
+	recorder.Reset()
+	recorder.Add(1)
+	clk.Add(10 * time.Second)
+
+	windowed = recorder.RateOver(time.Second)
+	require.False(t, windowed.IsZero())
+	require.InDelta(t, 0.1, windowed.Per(time.Second), 1e-9)
+}
+
+func TestRecorder_Snapshot(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	count, elapsed, epoch := recorder.Snapshot()
+	require.Zero(t, count)
+	require.Zero(t, elapsed)
+	require.Zero(t, epoch)
+
+	clk.Add(time.Second)
+	recorder.Add(123)
+
+	// Snapshots do not disturb the recorder's state.
+	for i := 0; i < 3; i++ {
+		count, elapsed, epoch = recorder.Snapshot()
+		require.EqualValues(t, 123, count)
+		require.Equal(t, time.Second, elapsed)
+		require.Zero(t, epoch)
+	}
+
+	recorder.Reset()
+	clk.Add(time.Second)
+
+	count, elapsed, epoch = recorder.Snapshot()
+	require.Zero(t, count)
+	require.Equal(t, time.Second, elapsed)
+	require.Equal(t, int64(time.Second), epoch)
+}
+
+func TestRecorder_Restore(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder *rate.Recorder
+	)
+
+	clk.SetNanotime(int64(time.Minute))
+	recorder = rate.NewRecorder(rate.WithClock(clk))
+	clk.Add(10 * time.Second)
+	recorder.Add(1000)
+	count, _, epoch := recorder.Snapshot()
+
+	// A recorder restored from a snapshot continues to report the same rate.
+	restored := rate.NewRecorder(
+		rate.WithClock(clk),
+		rate.WithInitialCount(count),
+		rate.WithEpoch(epoch),
+	)
+	require.Equal(t, recorder.Rate(), restored.Rate())
+	require.EqualValues(t, 100, restored.Rate().Per(time.Second))
+
+	clk.Add(10 * time.Second)
+	restored.Add(1000)
+	require.EqualValues(t, 100, restored.Rate().Per(time.Second))
+
+	// The restored state only applies to the first epoch.
+	restored.Reset()
+	count, elapsed, epoch := restored.Snapshot()
+	require.Zero(t, count)
+	require.Zero(t, elapsed)
+	require.Equal(t, clk.Nanotime(), epoch)
+
+	// Epochs in the future are replaced by the current time.
+	restored = rate.NewRecorder(
+		rate.WithClock(clk),
+		rate.WithEpoch(clk.Nanotime()+int64(time.Hour)),
+	)
+	_, elapsed, epoch = restored.Snapshot()
+	require.Zero(t, elapsed)
+	require.Equal(t, clk.Nanotime(), epoch)
+}
+
+func TestRecorder_Restore_WithDecay(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(
+			rate.WithClock(clk),
+			rate.WithDecay(time.Second),
+			rate.WithInitialCount(1000),
+		)
+	)
+
+	// The initial count decays from the time the recorder was created.
+	count, _, _ := recorder.Snapshot()
+	require.EqualValues(t, 1000, count)
+
+	clk.Add(time.Second)
+	count, _, _ = recorder.Snapshot()
+	require.EqualValues(t, 500, count)
+}
+
+func TestDefaultOptions_SharedClock(t *testing.T) {
+	// Defaults share the system's monotonic clock rather than allocating a
+	// new clock each time.
+	require.Same(t, clock.Monotonic(), rate.DefaultOptions().Clock)
+	require.Same(t, rate.DefaultOptions().Clock, rate.DefaultOptions().Clock)
+}
+
+func TestOptions_Restore(t *testing.T) {
+	options := rate.DefaultOptions().With(
+		rate.WithInitialCount(0),
+		rate.WithEpoch(0),
+	)
+	require.Zero(t, options.InitialCount)
+	require.NotNil(t, options.Epoch)
+	require.Zero(t, *options.Epoch)
+
+	options = rate.DefaultOptions().With(
+		rate.WithInitialCount(123),
+		rate.WithEpoch(456),
+	)
+	require.EqualValues(t, 123, options.InitialCount)
+	require.EqualValues(t, 456, *options.Epoch)
+
+	epoch := int64(1011)
+	options = rate.DefaultOptions().With(rate.Options{
+		InitialCount: 789,
+		Epoch:        &epoch,
+	})
+	require.EqualValues(t, 789, options.InitialCount)
+	require.EqualValues(t, 1011, *options.Epoch)
+
+	// Options without an epoch leave it unset.
+	require.Nil(t, rate.DefaultOptions().With(rate.Options{}).Epoch)
+}
+
+func TestRecorder_Restore_ZeroEpoch(t *testing.T) {
+	clk := clock.NewFakeClock()
+	clk.Add(10 * time.Second)
+
+	// A zero epoch is valid, e.g. the initial time of a fake clock.
+	recorder := rate.NewRecorder(rate.WithClock(clk), rate.WithEpoch(0))
+	_, elapsed, epoch := recorder.Snapshot()
+	require.Zero(t, epoch)
+	require.Equal(t, 10*time.Second, elapsed)
+}
+
+func TestRecorder_WaitForRate(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk, rate.WithPollInterval(time.Second))
+		errs     = make(chan error, 1)
+	)
+
+	go func() {
+		errs <- recorder.WaitForRate(context.Background(), 100, time.Second)
+	}()
+
+	// Wait for the waiter's ticker before advancing the clock. The target rate
+	// has not been met, so the waiter cannot have returned.
+	clk.BlockUntil(1)
+	clk.Add(time.Second)
+	select {
+	case err := <-errs:
+		require.FailNow(t, "unexpected return", "err: %v", err)
+	default:
+	}
+
+	// Exceed the target rate; the waiter observes it by its next poll at the
+	// latest, because any tick it has yet to handle is handled after the add.
+	recorder.Add(1_000)
+	clk.Add(time.Second)
+	require.NoError(t, <-errs)
+
+	// The target is already met; this should return immediately.
+	require.NoError(t, recorder.WaitForRate(context.Background(), 1, time.Second))
+}
+
+func TestRecorder_WaitForRate_ContextCanceled(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := recorder.WaitForRate(ctx, 100, time.Second)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestRecorderRealTime(t *testing.T) {
 	recorder := rate.NewRecorder()
 	recorder.Reset()
@@ -78,3 +500,156 @@ func TestRecorderRealTime(t *testing.T) {
 	rate := recorder.Reset()
 	require.True(t, rate.Per(time.Nanosecond) > 0.0)
 }
+
+func TestRate_IsZero(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(rate.WithClock(clk))
+	)
+
+	// A fresh recorder reports a well-defined zero rate, both with and
+	// without elapsed time.
+	require.True(t, rate.Rate{}.IsZero())
+	for _, have := range []rate.Rate{recorder.Rate(), recorder.Reset()} {
+		require.True(t, have.IsZero())
+		require.Zero(t, have.Per(time.Second))
+		require.Zero(t, have.Per(0))
+	}
+
+	clk.Add(time.Second)
+	require.True(t, recorder.Rate().IsZero())
+	require.Zero(t, recorder.Rate().Per(time.Second))
+
+	recorder.Add(1)
+	require.False(t, recorder.Rate().IsZero())
+	require.True(t, recorder.Reset().Scale(0).IsZero())
+}
+
+func TestRate_Per(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	// Zero values and zero elapsed time report a zero rate.
+	require.Zero(t, rate.Rate{}.Per(time.Second))
+	recorder.Add(1_000)
+	require.Zero(t, recorder.Rate().Per(time.Second))
+
+	// Normal elapsed time.
+	clk.Add(time.Second)
+	require.EqualValues(t, 1_000, recorder.Rate().Per(time.Second))
+
+	// Negative elapsed time, e.g. from a wall clock moving backward.
+	clk.Add(-2 * time.Second)
+	require.Zero(t, recorder.Rate().Per(time.Second))
+}
+
+func TestRate_Add(t *testing.T) {
+	var (
+		clk = clock.NewFakeClock()
+		a   = rate.NewRecorderWithClock(clk)
+		b   = rate.NewRecorderWithClock(clk)
+	)
+
+	// Two shards measured over the same window: the combined rate is the sum
+	// of the individual rates.
+	a.Add(1_000)
+	b.Add(3_000)
+	clk.Add(time.Second)
+
+	combined := a.Rate().Add(b.Rate())
+	require.EqualValues(t, 4_000, combined.Per(time.Second))
+	require.EqualValues(t, combined, b.Rate().Add(a.Rate()))
+
+	// Two shards measured over different windows: the combined count is
+	// spread over the longer window. Here, a has been running for 4s and b for
+	// 1s, so the combined rate is (1000+2000)/4s.
+	clk.Add(2 * time.Second)
+	b.Reset()
+	b.Add(2_000)
+	clk.Add(time.Second)
+
+	combined = a.Rate().Add(b.Rate())
+	require.EqualValues(t, 750, combined.Per(time.Second))
+	require.EqualValues(t, 3_000, combined.Per(4*time.Second))
+
+	// Adding a zero rate is a no-op.
+	require.Equal(t, a.Rate(), a.Rate().Add(rate.Rate{}))
+}
+
+func TestRate_Scale(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorderWithClock(clk)
+	)
+
+	recorder.Add(1_000)
+	clk.Add(time.Second)
+
+	current := recorder.Rate()
+	require.EqualValues(t, 2_000, current.Scale(2).Per(time.Second))
+	require.EqualValues(t, 500, current.Scale(0.5).Per(time.Second))
+	require.EqualValues(t, 0, current.Scale(0).Per(time.Second))
+	require.EqualValues(t, 1_000, current.Per(time.Second))
+
+	// Fractional counts are kept rather than rounded.
+	require.InDelta(t, 1.4, current.Scale(0.0014).Per(time.Second), 1e-9)
+	require.False(t, current.Scale(0.0001).IsZero())
+
+	// Scaling a fractional rate keeps its fraction.
+	recorder.Reset()
+	recorder.AddFloat(0.25)
+	clk.Add(time.Second)
+
+	fractional := recorder.Rate()
+	require.InDelta(t, 0.75, fractional.Scale(3).Per(time.Second), 1e-9)
+	require.InDelta(t, 0.125, fractional.Scale(0.5).Per(time.Second), 1e-9)
+	require.InDelta(t, 0.25, fractional.Scale(1).Per(time.Second), 1e-9)
+}
+
+func TestRate_JSON(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewRecorder(rate.WithClock(clk))
+	)
+
+	clk.Add(2 * time.Second)
+	recorder.Add(10)
+	want := recorder.Rate()
+
+	data, err := json.Marshal(want)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"count":10,"elapsed_ns":2000000000,"per_second":5}`, string(data))
+
+	var have rate.Rate
+	require.NoError(t, json.Unmarshal(data, &have))
+	require.Equal(t, want, have)
+
+	// The count and elapsed time are authoritative.
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"count":10,"elapsed_ns":1000000000,"per_second":123}`),
+		&have,
+	))
+	require.EqualValues(t, 10, have.Per(time.Second))
+
+	// Rates without elapsed time report zero per second.
+	data, err = json.Marshal(rate.Rate{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"count":0,"elapsed_ns":0,"per_second":0}`, string(data))
+
+	// Fractional counts are encoded separately.
+	recorder.AddFloat(0.5)
+	want = recorder.Rate()
+	data, err = json.Marshal(want)
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{"count":10,"count_fraction":0.5,"elapsed_ns":2000000000,"per_second":5.25}`,
+		string(data),
+	)
+	require.NoError(t, json.Unmarshal(data, &have))
+	require.Equal(t, want, have)
+
+	require.Error(t, json.Unmarshal([]byte(`{"count":"x"}`), &have))
+}
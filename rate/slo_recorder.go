@@ -0,0 +1,148 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate
+
+import (
+	"sync"
+	"time"
+)
+
+// An SLORecorder records observed values and reports the fraction of them that
+// met a service level objective, i.e. that were at or below a threshold: for
+// example, the fraction of requests that completed within 100ms. By default,
+// all values observed since the recorder was last reset are weighted equally;
+// with [WithDecay], older values are weighted less than recent ones, so that
+// compliance reflects a sliding window of roughly the configured half-life.
+// Observed values are also recorded by a [DistRecorder], whose quantiles can
+// be used to compare the threshold with the observed distribution.
+type SLORecorder struct {
+	dist      *DistRecorder
+	threshold float64
+	good      int64
+	decayGood *decayingCount // nil unless decaying
+	decayAll  *decayingCount // nil unless decaying
+	mu        sync.Mutex
+}
+
+// NewSLORecorder creates a new [SLORecorder] that considers observed values at
+// or below threshold to meet the objective, configured by the given options.
+func NewSLORecorder(threshold float64, opts ...Option) *SLORecorder {
+	options := DefaultOptions().With(opts...)
+	r := &SLORecorder{
+		dist:      NewDistRecorder(WithClock(options.Clock)),
+		threshold: threshold,
+	}
+	if options.HalfLife > 0 {
+		r.decayGood = &decayingCount{
+			halfLife: float64(options.HalfLife),
+		}
+		r.decayAll = &decayingCount{
+			halfLife: float64(options.HalfLife),
+		}
+	}
+	r.Reset()
+	return r
+}
+
+// Observe records v.
+func (r *SLORecorder) Observe(v float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dist.Observe(v)
+
+	var good float64
+	if v <= r.threshold {
+		r.good++
+		good = 1
+	}
+
+	if r.decayAll != nil {
+		now := r.dist.clock.Nanotime()
+		r.decayGood.add(now, good)
+		r.decayAll.add(now, 1)
+	}
+}
+
+// Compliance returns the fraction of observed values that were at or below
+// the recorder's threshold, in [0, 1]. Without [WithDecay], every value
+// observed since the recorder was last reset is weighted equally; with it,
+// each value's weight halves every half-life after it was observed. If no
+// values have been observed, nothing has violated the objective, and
+// Compliance returns 1; use [SLORecorder.Count] to distinguish this case.
+func (r *SLORecorder) Compliance() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.decayAll != nil {
+		var (
+			now = r.dist.clock.Nanotime()
+			all = r.decayAll.load(now)
+		)
+		if all == 0 {
+			return 1
+		}
+		return r.decayGood.load(now) / all
+	}
+
+	count := r.dist.Count()
+	if count == 0 {
+		return 1
+	}
+	return float64(r.good) / float64(count)
+}
+
+// Count returns the number of values observed since the recorder was last
+// reset. The count is not affected by [WithDecay].
+func (r *SLORecorder) Count() int64 {
+	return r.dist.Count()
+}
+
+// Elapsed returns the time elapsed since the recorder was last reset.
+func (r *SLORecorder) Elapsed() time.Duration {
+	return r.dist.Elapsed()
+}
+
+// Quantile returns an estimate of the q-quantile of the values observed since
+// the recorder was last reset; see [DistRecorder.Quantile].
+func (r *SLORecorder) Quantile(q float64) float64 {
+	return r.dist.Quantile(q)
+}
+
+// Threshold returns the recorder's threshold.
+func (r *SLORecorder) Threshold() float64 {
+	return r.threshold
+}
+
+// Reset discards all observed values and resets the recorder's epoch.
+func (r *SLORecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dist.Reset()
+	r.good = 0
+
+	if r.decayAll != nil {
+		now := r.dist.clock.Nanotime()
+		r.decayGood.seed(now, 0)
+		r.decayAll.seed(now, 0)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package rate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/chrono/clock"
+	"go.mway.dev/chrono/rate"
+)
+
+func TestSLORecorder(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewSLORecorder(100, rate.WithClock(clk))
+	)
+
+	// An empty recorder has not violated its objective.
+	require.Zero(t, recorder.Count())
+	require.Equal(t, 1.0, recorder.Compliance())
+	require.Equal(t, 100.0, recorder.Threshold())
+
+	// Values at the threshold meet the objective.
+	for _, v := range []float64{10, 50, 100, 101, 99, 250, 0, 100.5} {
+		recorder.Observe(v)
+	}
+	clk.Add(time.Second)
+
+	require.EqualValues(t, 8, recorder.Count())
+	require.InDelta(t, 5.0/8, recorder.Compliance(), 1e-9)
+	require.Equal(t, time.Second, recorder.Elapsed())
+
+	recorder.Reset()
+	require.Zero(t, recorder.Count())
+	require.Zero(t, recorder.Elapsed())
+	require.Equal(t, 1.0, recorder.Compliance())
+
+	recorder.Observe(1_000)
+	require.Zero(t, recorder.Compliance())
+}
+
+func TestSLORecorder_WithDecay(t *testing.T) {
+	var (
+		clk      = clock.NewFakeClock()
+		recorder = rate.NewSLORecorder(
+			100,
+			rate.WithClock(clk),
+			rate.WithDecay(time.Second),
+		)
+	)
+
+	require.Equal(t, 1.0, recorder.Compliance())
+
+	// Four good values decay to a weight of two after one half-life, so two
+	// bad values observed then halve compliance.
+	for i := 0; i < 4; i++ {
+		recorder.Observe(50)
+	}
+	clk.Add(time.Second)
+	recorder.Observe(500)
+	recorder.Observe(500)
+	require.InDelta(t, 0.5, recorder.Compliance(), 1e-9)
+
+	// Good and bad values decay at the same rate, so time alone does not
+	// change compliance.
+	clk.Add(time.Second)
+	require.InDelta(t, 0.5, recorder.Compliance(), 1e-9)
+
+	// A recent good value outweighs older ones: 1+1 good out of 2+1 total.
+	recorder.Observe(100)
+	require.InDelta(t, 2.0/3, recorder.Compliance(), 1e-9)
+
+	// The count is unaffected by decay.
+	require.EqualValues(t, 7, recorder.Count())
+
+	recorder.Reset()
+	require.Zero(t, recorder.Count())
+	require.Equal(t, 1.0, recorder.Compliance())
+
+	recorder.Observe(1_000)
+	require.Zero(t, recorder.Compliance())
+}
+
+func TestSLORecorder_Quantile(t *testing.T) {
+	recorder := rate.NewSLORecorder(100, rate.WithClock(clock.NewFakeClock()))
+	require.Zero(t, recorder.Quantile(0.5))
+
+	for _, v := range []float64{10, 20, 30, 40, 500} {
+		recorder.Observe(v)
+	}
+
+	// The median meets the objective, but the tail does not.
+	require.Equal(t, 30.0, recorder.Quantile(0.5))
+	require.Equal(t, 500.0, recorder.Quantile(1))
+	require.InDelta(t, 0.8, recorder.Compliance(), 1e-9)
+
+	recorder.Reset()
+	require.Zero(t, recorder.Quantile(0.5))
+}